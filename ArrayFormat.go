@@ -0,0 +1,182 @@
+package strongparams
+
+import (
+	"mime/multipart"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ArrayFormat declares the encoding of the array values.
+type ArrayFormat int
+
+const (
+	// ArrayMulti declares the arrays of repeated keys, eg. "ids=1&ids=2", "ids[]=1&ids[]=2" or "ids[0]=1&ids[1]=2". It
+	// is the default format.
+	ArrayMulti ArrayFormat = iota
+	// ArrayComma declares the comma delimited arrays, eg. "ids=1,2", as the OpenAPI `form` style with explode=false.
+	ArrayComma
+	// ArrayPipe declares the pipe delimited arrays, eg. "ids=1|2", as the OpenAPI `pipeDelimited` style.
+	ArrayPipe
+	// ArraySpace declares the space delimited arrays, eg. "ids=1%202", as the OpenAPI `spaceDelimited` style.
+	ArraySpace
+)
+
+func (this ArrayFormat) split(value string) []string {
+	switch this {
+	case ArrayComma:
+		return strings.Split(value, ",")
+	case ArrayPipe:
+		return strings.Split(value, "|")
+	case ArraySpace:
+		return strings.Split(value, " ")
+	}
+	return []string{value}
+}
+
+// WithArrayFormat declares the encoding of the array values. The format is applied on the `paths` array keys, eg.
+// "ids" or "user[ids]", or on all the array keys if no paths are passed. The paths are the keys before applying
+// Require. The format will be used on the returned *StrongParams struct pointer not on the receiver parameter.
+//   Go: Params().WithArrayFormat(ArrayComma, "ids").Permit("ids:[]")
+//   Query: ids=1,2&ids[]=3&ids[5]=4
+//   Processed as: ids[]=1&ids[]=2&ids[]=3&ids[]=4
+// All the array encodings are normalized to the "key[]" keys before applying Permit. The array keys are the keys
+// declared as arrays by the permit rules, eg. "ids:[]", the keys ending with "[]" and the `paths` keys.
+func (this *StrongParams) WithArrayFormat(format ArrayFormat, paths ...string) *StrongParams {
+	params := this.clone()
+	if len(paths) == 0 {
+		params.arrayFormat = format
+		return params
+	}
+
+	arrayFormats := make(map[string]ArrayFormat, len(this.arrayFormats)+len(paths))
+	for path, pathFormat := range this.arrayFormats {
+		arrayFormats[path] = pathFormat
+	}
+	for _, path := range paths {
+		arrayFormats[strings.TrimSuffix(path, "[]")] = format
+	}
+	params.arrayFormats = arrayFormats
+	return params
+}
+
+var rgxArrayElementKey = regexp.MustCompile("^(.+?)(?:\\[\\]|\\[(\\d+)\\])$")
+
+type arrayEntry struct {
+	key string
+	idx int
+}
+
+// normalizedArrays returns the sourceValues with the array keys normalized to the "key[]" keys and the values split by
+// the array format. The `isArray` parameter declares the additional array keys, eg. by the permit rules. The normalized
+// values are checked by the limits.
+func (this *strongParams) normalizedArrays(source *sourceValues, isArray func(arrayKey string) bool) *sourceValues {
+	if source.error != nil {
+		return source
+	}
+
+	arrays := map[string][]arrayEntry{}
+	for key := range source.values {
+		base, idx := key, -1
+		if match := rgxArrayElementKey.FindStringSubmatch(key); match != nil {
+			base = match[1]
+			if match[2] != "" {
+				idx, _ = strconv.Atoi(match[2])
+			}
+		}
+
+		_, isConfigured := this.arrayFormats[base]
+		isBracketed := strings.HasSuffix(key, "[]")
+		if !isConfigured && !isBracketed && !(isArray != nil && isArray(base+"[]")) {
+			continue
+		}
+		arrays[base] = append(arrays[base], arrayEntry{key, idx})
+	}
+
+	changed := false
+	for base, entries := range arrays {
+		if len(entries) > 1 || entries[0].key != base+"[]" || this.arrayFormatOf(base) != ArrayMulti {
+			changed = true
+		}
+	}
+	if !changed {
+		return source
+	}
+
+	normalized := *source
+	normalized.values = make(url.Values, len(source.values))
+	normalized.typed = nil
+	normalized.files = nil
+	normalized.origins = nil
+	rename := map[string]string{}
+	for key, value := range source.values {
+		normalized.values[key] = value
+		rename[key] = key
+	}
+
+	for base, entries := range arrays {
+		sort.Slice(entries, func(i, j int) bool {
+			if entries[i].idx != entries[j].idx {
+				return entries[i].idx < entries[j].idx
+			}
+			return entries[i].key < entries[j].key
+		})
+
+		arrayKey, format := base+"[]", this.arrayFormatOf(base)
+		var values []string
+		for _, entry := range entries {
+			for _, value := range source.values[entry.key] {
+				values = append(values, format.split(value)...)
+			}
+			delete(normalized.values, entry.key)
+			rename[entry.key] = arrayKey
+		}
+		normalized.values[arrayKey] = values
+		if values == nil {
+			normalized.values[arrayKey] = []string{}
+		}
+	}
+
+	keys := make([]string, 0, len(rename))
+	for key := range rename {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		normalizedKey := rename[key]
+		if value, ok := source.typed[key]; ok {
+			if normalized.typed == nil {
+				normalized.typed = map[string][]interface{}{}
+			}
+			normalized.typed[normalizedKey] = append(normalized.typed[normalizedKey], value...)
+		}
+		if value, ok := source.files[key]; ok {
+			if normalized.files == nil {
+				normalized.files = map[string][]*multipart.FileHeader{}
+			}
+			normalized.files[normalizedKey] = append(normalized.files[normalizedKey], value...)
+		}
+		if value, ok := source.origins[key]; ok {
+			if normalized.origins == nil {
+				normalized.origins = map[string]string{}
+			}
+			normalized.origins[normalizedKey] = value
+		}
+	}
+
+	// The split values are limited again as the elements of the arrays, eg. by MaxArrayLen
+	limited := this.limited(&normalized)
+	if limited.error != nil {
+		source.removeFiles()
+	}
+	return limited
+}
+
+func (this *strongParams) arrayFormatOf(base string) ArrayFormat {
+	if format, ok := this.arrayFormats[base]; ok {
+		return format
+	}
+	return this.arrayFormat
+}
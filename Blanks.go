@@ -0,0 +1,118 @@
+package strongparams
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// BlankHandling declares how the blank values are handled after applying Permit and before decoding. The handlings are
+// combined with the `|` operator, eg. CompactBlank | StripArrayPlaceholder.
+type BlankHandling int
+
+const (
+	// CompactBlank drops the blank values, ie. the empty and the whitespace only strings and the nulls, as done by the
+	// Rails `compact_blank`. The keys left without values are dropped.
+	CompactBlank BlankHandling = 1 << iota
+	// EmptyAsNil leaves the pointer struct fields of the empty values nil. The Tree targets receive nil values instead
+	// of the empty strings.
+	EmptyAsNil
+	// StripArrayPlaceholder drops the empty values of the array keys, eg. the hidden "tags[]=" placeholder emitted by
+	// the Rails style form helpers for the empty collections. The Tree targets receive empty arrays.
+	StripArrayPlaceholder
+)
+
+// WithBlankHandling declares how the blank values are handled after applying Permit and before decoding. The handling
+// will be used on the returned *StrongParams struct pointer not on the receiver parameter.
+//   Go: Params().WithBlankHandling(CompactBlank | StripArrayPlaceholder).Permit("name, middle_name, tags:[]")
+//   Query: name=John&middle_name=&tags[]=&tags[]=a
+//   Decoded as: name=John&tags[]=a
+func (this *StrongParams) WithBlankHandling(handling BlankHandling) *StrongParams {
+	params := this.clone()
+	params.blankHandling = handling
+	return params
+}
+
+// blanksHandled returns the sourceValues with the blank values handled by the `handling`. The empty values replaced
+// with nil by EmptyAsNil are held as the typed values.
+func (this *sourceValues) blanksHandled(handling BlankHandling) *sourceValues {
+	if handling == 0 {
+		return this
+	}
+
+	handled := *this
+	handled.values = make(url.Values, len(this.values))
+	handled.typed = nil
+
+	keys := make([]string, 0, len(this.values)+len(this.typed))
+	for key := range this.values {
+		keys = append(keys, key)
+	}
+	for key := range this.typed {
+		if _, ok := this.values[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	for _, key := range keys {
+		isArrayKey := strings.HasSuffix(key, "[]")
+		values := []string{}
+		var typed []interface{}
+		isTyped := false
+
+		for _, value := range typedValues(this.typed, key, this.values[key]) {
+			stringValue, isString := value.(string)
+			switch {
+			case handling&CompactBlank != 0 && (value == nil || (isString && strings.TrimSpace(stringValue) == "")):
+				continue
+			case handling&StripArrayPlaceholder != 0 && isArrayKey && isString && stringValue == "":
+				continue
+			case handling&EmptyAsNil != 0 && isString && stringValue == "":
+				value = nil
+			}
+
+			if value == nil || !isString {
+				isTyped = true
+			}
+			if value != nil {
+				values = append(values, stringifyTyped(value))
+			}
+			typed = append(typed, value)
+		}
+
+		if len(typed) == 0 && handling&CompactBlank != 0 {
+			continue
+		} else if len(typed) == 0 {
+			// The stripped array keys are kept as the empty arrays
+			isTyped = true
+		}
+		handled.values[key] = values
+		if _, ok := this.typed[key]; ok || isTyped {
+			if handled.typed == nil {
+				handled.typed = map[string][]interface{}{}
+			}
+			handled.typed[key] = typed
+		}
+	}
+
+	return &handled
+}
+
+// emptyAsNil drops the dot notation `values` keys of the empty values addressing the pointer fields of the `target`
+// struct.
+func emptyAsNil(values url.Values, target interface{}, aliasTag string) {
+	for key, value := range values {
+		isEmpty := true
+		for _, stringValue := range value {
+			isEmpty = isEmpty && stringValue == ""
+		}
+		if !isEmpty {
+			continue
+		}
+
+		fieldType, ok := fieldTypeByKey(reflect.TypeOf(target), strings.Split(key, "."), aliasTag)
+		if ok && fieldType.Kind() == reflect.Ptr {
+			delete(values, key)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package strongparams
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/vellotis/go-strongparams/permitter"
+	"reflect"
+	"strings"
+)
+
+// CheckError is returned by Check when the permit rules are inconsistent with the target struct.
+type CheckError struct {
+	Target     reflect.Type
+	Mismatches []permitter.Mismatch
+}
+
+func (this *CheckError) Error() string {
+	mismatches := make([]string, len(this.Mismatches))
+	for idx, mismatch := range this.Mismatches {
+		mismatches[idx] = mismatch.String()
+	}
+	return fmt.Sprintf("permit rules are inconsistent with `%s`: %s", this.Target, strings.Join(mismatches, "; "))
+}
+
+// Check verifies that the `permitRule` rule is consistent with the `target` struct and returns a *CheckError listing
+// the permitted paths without a matching field, the `required` fields dropped by the rule and the array/object shape
+// mismatches. It is meant to be run at startup so that typos in the rules don't show up as silently empty fields.
+//   type User struct {
+//       Name  string `params:"name,required"`
+//       Email string `params:"email"`
+//   }
+//   err := Check("name, emial", &User{})
+//   // permit rules are inconsistent with `User`: `emial`: no matching field in `User`
+// The `permitRule` rule is expected to be the rule passed to StrongParamsRequired.Permit, ie. the rule is applied on
+// the target struct root. Look permitter.Check for the details.
+func Check(permitRule string, target interface{}) error {
+	parsed := &strongParamsRequiredAndPermitted{}
+	if err := parsed.parsePermitRules([]string{permitRule}); err != nil {
+		return err
+	}
+	return Params().check(parsed.permitRules, target)
+}
+
+// MustCheck is equivalent to Check but instead of returning an error it panics with the error. It is meant to be
+// called from `init()`.
+func MustCheck(permitRule string, target interface{}) {
+	if err := Check(permitRule, target); err != nil {
+		panic(err)
+	}
+}
+
+func (this *strongParams) check(rules permitter.Permittable, target interface{}) error {
+	if target == nil {
+		return errors.New("`target` argument cannot be nil")
+	}
+
+	targetType := reflect.TypeOf(target)
+	for targetType.Kind() == reflect.Ptr {
+		targetType = targetType.Elem()
+	}
+	if targetType.Kind() != reflect.Struct {
+		return errors.Errorf("`target` argument must be a struct, got `%s`", targetType)
+	}
+
+	mismatches := permitter.CheckWithAliasTag(rules, targetType, this.aliasTag)
+	if len(mismatches) > 0 {
+		return &CheckError{
+			Target:     targetType,
+			Mismatches: mismatches,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package strongparams
+
+import (
+	"mime/multipart"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WithCollectionNormalization instructs the mechanism to normalize the collections into dense ordered slices before
+// decoding. The option will be used on the returned *StrongParams struct pointer not on the receiver parameter.
+//   Query: items[3][name]=a&items[99999][name]=b
+//   Decoded as: items[0][name]=a&items[1][name]=b
+// The permit array rules additionally match the Rails nested attributes style hash-keyed collections:
+//   Go: Params().WithCollectionNormalization().Permit("items:[name]")
+//   Query: items[abc123][name]=x&items[def456][name]=y
+//   Decoded as: items[0][name]=x&items[1][name]=y
+// The numeric keys are ordered by their values followed by the hash keys in the ascending order. Without the permit
+// rules only the numeric keys are considered as the collection elements.
+func (this *StrongParams) WithCollectionNormalization() *StrongParams {
+	params := this.clone()
+	params.normalizeCollections = true
+	return params
+}
+
+var rgxCollectionIndex = regexp.MustCompile("^\\d+$")
+
+// numericCollectionPositions returns the indexes of the numeric segments of the `key` except the root segment.
+func numericCollectionPositions(key string) []int {
+	var positions []int
+	for idx, segment := range splitKey(key) {
+		if idx > 0 && rgxCollectionIndex.MatchString(segment) {
+			positions = append(positions, idx)
+		}
+	}
+	return positions
+}
+
+// normalizedCollections returns the sourceValues with the collection element keys, resolved by the
+// collectionPositions of the receiver or numericCollectionPositions, replaced with the dense indexes. The values of the
+// collection elements of the last segment, eg. "ids[7]", are collected to an array key, eg. "ids[]", in the order of
+// the indexes as the indexed values are not decoded by schema.Decoder.
+func (this *sourceValues) normalizedCollections() *sourceValues {
+	positions := this.collectionPositions
+	if positions == nil {
+		positions = numericCollectionPositions
+	}
+
+	keys := make([]string, 0, len(this.values)+len(this.files))
+	for key := range this.values {
+		keys = append(keys, key)
+	}
+	for key := range this.files {
+		if _, ok := this.values[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	renamed, elementIndexes := denseCollectionKeys(keys, positions)
+	if len(renamed) == 0 && len(elementIndexes) == 0 {
+		return this
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return elementIndexes[keys[i]] < elementIndexes[keys[j]]
+	})
+
+	normalized := *this
+	normalized.values = make(url.Values, len(this.values))
+	normalized.typed = nil
+	normalized.files = nil
+	normalized.origins = nil
+	for _, key := range keys {
+		normalizedKey := renamed[key]
+		if normalizedKey == "" {
+			normalizedKey = key
+		}
+
+		if value, ok := this.values[key]; ok {
+			normalized.values[normalizedKey] = append(normalized.values[normalizedKey], value...)
+		}
+		if value, ok := this.typed[key]; ok {
+			if normalized.typed == nil {
+				normalized.typed = map[string][]interface{}{}
+			}
+			normalized.typed[normalizedKey] = append(normalized.typed[normalizedKey], value...)
+		}
+		if value, ok := this.files[key]; ok {
+			if normalized.files == nil {
+				normalized.files = map[string][]*multipart.FileHeader{}
+			}
+			normalized.files[normalizedKey] = append(normalized.files[normalizedKey], value...)
+		}
+		if value, ok := this.origins[key]; ok {
+			if normalized.origins == nil {
+				normalized.origins = map[string]string{}
+			}
+			normalized.origins[normalizedKey] = value
+		}
+	}
+	return &normalized
+}
+
+// denseCollectionKeys returns the `keys` which collection element segments, resolved by `positions`, are replaced with
+// the dense indexes. The keys not changed are omitted. The keys of which last segment is a collection element are
+// replaced with the array keys, eg. "ids[]", and their dense indexes are returned as the element indexes.
+func denseCollectionKeys(keys []string, positions func(key string) []int) (map[string]string, map[string]int) {
+	segments := make(map[string][]string, len(keys))
+	levels := map[int][]string{}
+	maxLevel := -1
+	for _, key := range keys {
+		keyPositions := positions(key)
+		if len(keyPositions) == 0 {
+			continue
+		}
+		segments[key] = splitKey(key)
+		for _, position := range keyPositions {
+			if position > 0 && position < len(segments[key]) && segments[key][position] != "" {
+				levels[position] = append(levels[position], key)
+				if position > maxLevel {
+					maxLevel = position
+				}
+			}
+		}
+	}
+
+	elementIndexes := map[string]int{}
+	for level := 1; level <= maxLevel; level++ {
+		collections := map[string][]string{}
+		for _, key := range levels[level] {
+			collection := joinSegments(segments[key][:level])
+			collections[collection] = append(collections[collection], segments[key][level])
+		}
+
+		indexes := map[string]map[string]int{}
+		for collection, elements := range collections {
+			indexes[collection] = denseIndexes(elements)
+		}
+		for _, key := range levels[level] {
+			idx := indexes[joinSegments(segments[key][:level])][segments[key][level]]
+			if level == len(segments[key])-1 {
+				elementIndexes[key] = idx
+				segments[key][level] = ""
+			} else {
+				segments[key][level] = strconv.Itoa(idx)
+			}
+		}
+	}
+
+	renamed := map[string]string{}
+	for key, keySegments := range segments {
+		if renamedKey := joinSegments(keySegments); renamedKey != key {
+			renamed[key] = renamedKey
+		}
+	}
+	return renamed, elementIndexes
+}
+
+// denseIndexes maps the collection `elements` to the dense indexes. The numeric elements are ordered by their values
+// followed by the hash elements in the ascending order.
+func denseIndexes(elements []string) map[string]int {
+	var numeric, hashes []string
+	seen := map[string]bool{}
+	for _, element := range elements {
+		if seen[element] {
+			continue
+		}
+		seen[element] = true
+		if rgxCollectionIndex.MatchString(element) {
+			numeric = append(numeric, element)
+		} else {
+			hashes = append(hashes, element)
+		}
+	}
+	sortIndexSegments(numeric)
+	sort.Strings(hashes)
+
+	indexes := make(map[string]int, len(seen))
+	for idx, element := range append(numeric, hashes...) {
+		indexes[element] = idx
+	}
+	return indexes
+}
+
+func joinSegments(segments []string) string {
+	var builder strings.Builder
+	for idx, segment := range segments {
+		if idx == 0 {
+			builder.WriteString(segment)
+		} else {
+			builder.WriteString("[" + segment + "]")
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,263 @@
+package strongparams
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/thoas/go-funk"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// CrossFieldKind declares the kind of a cross-field rule.
+type CrossFieldKind int
+
+const (
+	// OneOfRule declares exactly one of the keys to be present, eg. "oneOf(email, phone)".
+	OneOfRule CrossFieldKind = iota + 1
+	// AnyOfRule declares at least one of the keys to be present, eg. "anyOf(email, phone)".
+	AnyOfRule
+	// ExclusiveRule declares at most one of the keys to be present, eg. "exclusive(email, phone)".
+	ExclusiveRule
+	// DependsOnRule declares the first key to require all the other keys to be present, eg.
+	// "dependsOn(end_date, start_date)".
+	DependsOnRule
+)
+
+func (this CrossFieldKind) String() string {
+	switch this {
+	case OneOfRule:
+		return "oneOf"
+	case AnyOfRule:
+		return "anyOf"
+	case ExclusiveRule:
+		return "exclusive"
+	case DependsOnRule:
+		return "dependsOn"
+	}
+	return "unknown"
+}
+
+// CrossFieldError describes a violated cross-field rule. The Keys are all the keys of the rule and the Present keys are
+// the ones found in the parameters. The keys are in the bracket notation before applying Require, eg. "user[email]".
+type CrossFieldError struct {
+	Kind    CrossFieldKind
+	Keys    []string
+	Present []string
+}
+
+func (this *CrossFieldError) Error() string {
+	switch this.Kind {
+	case OneOfRule:
+		return fmt.Sprintf("exactly one of %s must be present", quotedKeys(this.Keys))
+	case AnyOfRule:
+		return fmt.Sprintf("at least one of %s must be present", quotedKeys(this.Keys))
+	case ExclusiveRule:
+		return fmt.Sprintf("only one of %s may be present", quotedKeys(this.Keys))
+	case DependsOnRule:
+		return fmt.Sprintf("%s requires %s", quotedKeys(this.Keys[:1]), quotedKeys(this.Keys[1:]))
+	}
+	return fmt.Sprintf("unknown rule of %s", quotedKeys(this.Keys))
+}
+
+// CrossFieldErrors holds all the CrossFieldError errors of the processed parameters in the order of the declared rules.
+type CrossFieldErrors []*CrossFieldError
+
+func (this CrossFieldErrors) Error() string {
+	return errorListMessage("cross-field: ", this)
+}
+
+func (this CrossFieldErrors) Unwrap() []error {
+	return unwrapErrorList(this)
+}
+
+func quotedKeys(keys []string) string {
+	quoted := make([]string, len(keys))
+	for idx, key := range keys {
+		quoted[idx] = "`" + key + "`"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// crossFieldRule is a cross-field rule of the `keys` relative to the required key.
+type crossFieldRule struct {
+	kind CrossFieldKind
+	keys []string
+}
+
+func newCrossFieldRule(kind CrossFieldKind, keys []string) (crossFieldRule, error) {
+	rule := crossFieldRule{kind: kind, keys: funk.UniqString(keys)}
+	if funk.ContainsString(rule.keys, "") {
+		return rule, errors.Errorf("cross-field: empty key in rule `%s`", kind)
+	} else if kind != DependsOnRule && len(rule.keys) < 2 {
+		return rule, errors.Errorf("cross-field: rule `%s` requires at least two keys", kind)
+	} else if kind == DependsOnRule && len(rule.keys) < 2 {
+		return rule, errors.Errorf("cross-field: rule `%s` requires a key and its dependencies", kind)
+	}
+	return rule, nil
+}
+
+// verify returns the CrossFieldError if the rule is violated by the keys present in the `values`.
+func (this crossFieldRule) verify(values url.Values, untransformKey func(path string) string) *CrossFieldError {
+	var present []string
+	for _, key := range this.keys {
+		if isKeyPresent(values, key) {
+			present = append(present, key)
+		}
+	}
+
+	var violated bool
+	switch this.kind {
+	case OneOfRule:
+		violated = len(present) != 1
+	case AnyOfRule:
+		violated = len(present) == 0
+	case ExclusiveRule:
+		violated = len(present) > 1
+	case DependsOnRule:
+		violated = len(present) > 0 && present[0] == this.keys[0] && len(present) < len(this.keys)
+	}
+	if !violated {
+		return nil
+	}
+
+	err := &CrossFieldError{
+		Kind:    this.kind,
+		Keys:    make([]string, len(this.keys)),
+		Present: make([]string, len(present)),
+	}
+	for idx, key := range this.keys {
+		err.Keys[idx] = untransformKey(key)
+	}
+	for idx, key := range present {
+		err.Present[idx] = untransformKey(key)
+	}
+	return err
+}
+
+// isKeyPresent returns whether the `key` or any of its nested keys holds a non-empty value.
+func isKeyPresent(values url.Values, key string) bool {
+	for valueKey, value := range values {
+		if !isKeyUnder(strings.TrimSuffix(valueKey, "[]"), key) {
+			continue
+		}
+		for _, item := range value {
+			if item != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var rgxCrossFieldRule = regexp.MustCompile(
+	"(?:^|[\\s,])(?P<Kind>oneOf|anyOf|exclusive|dependsOn)\\s*\\((?P<Keys>[^()]*)\\)")
+var rgxRepeatedComma = regexp.MustCompile(",[\\s,]*,")
+
+var crossFieldKinds = map[string]CrossFieldKind{
+	"oneOf":     OneOfRule,
+	"anyOf":     AnyOfRule,
+	"exclusive": ExclusiveRule,
+	"dependsOn": DependsOnRule,
+}
+
+// extractCrossFieldRules returns the `permitRule` without the cross-field rules declared at the top level of the rule,
+// eg. "email, phone, oneOf(email, phone)", and the extracted rules.
+func extractCrossFieldRules(permitRule string) (string, []crossFieldRule, error) {
+	var crossFieldRules []crossFieldRule
+	rule := permitRule
+
+	for _, ruleResult := range rgxCrossFieldRule.FindAllStringSubmatchIndex(permitRule, -1) {
+		if !isTopLevel(permitRule[:ruleResult[0]]) {
+			return "", nil, errors.Errorf("cross-field: rules are allowed only at the top level of rule `%s`",
+				permitRule)
+		}
+
+		kindIdx := 2 * rgxCrossFieldRule.SubexpIndex("Kind")
+		keysIdx := 2 * rgxCrossFieldRule.SubexpIndex("Keys")
+		var keys []string
+		for _, key := range strings.Split(permitRule[ruleResult[keysIdx]:ruleResult[keysIdx+1]], ",") {
+			keys = append(keys, strings.Trim(strings.TrimSpace(key), "'"))
+		}
+
+		kind := crossFieldKinds[permitRule[ruleResult[kindIdx]:ruleResult[kindIdx+1]]]
+		crossFieldRule, err := newCrossFieldRule(kind, keys)
+		if err != nil {
+			return "", nil, err
+		}
+		crossFieldRules = append(crossFieldRules, crossFieldRule)
+		rule = strings.Replace(rule, strings.TrimLeft(permitRule[ruleResult[0]:ruleResult[1]], " \t\n,"), ",", 1)
+	}
+
+	if rule != permitRule {
+		rule = strings.Trim(rgxRepeatedComma.ReplaceAllString(rule, ","), " \t\n,")
+	}
+	return rule, crossFieldRules, nil
+}
+
+// isTopLevel returns whether the end of the `rulePrefix` is outside of any object or array literal.
+func isTopLevel(rulePrefix string) bool {
+	return strings.Count(rulePrefix, "{")+strings.Count(rulePrefix, "[") ==
+		strings.Count(rulePrefix, "}")+strings.Count(rulePrefix, "]")
+}
+
+// ExactlyOneOf instructs to require exactly one of the `keys` to be present in the permitted parameters, eg. either
+// email or phone. The keys are relative to the required key as the permit rules are. Equivalent to the permit rule
+// "oneOf(key1, key2)".
+func (this *StrongParamsRequiredAndPermitted) ExactlyOneOf(keys ...string) *StrongParamsRequiredAndPermitted {
+	return this.withCrossFieldRule(OneOfRule, keys)
+}
+
+// AtLeastOneOf instructs to require at least one of the `keys` to be present in the permitted parameters. Equivalent
+// to the permit rule "anyOf(key1, key2)".
+func (this *StrongParamsRequiredAndPermitted) AtLeastOneOf(keys ...string) *StrongParamsRequiredAndPermitted {
+	return this.withCrossFieldRule(AnyOfRule, keys)
+}
+
+// MutuallyExclusive instructs to reject the permitted parameters if more than one of the `keys` is present.
+// Equivalent to the permit rule "exclusive(key1, key2)".
+func (this *StrongParamsRequiredAndPermitted) MutuallyExclusive(keys ...string) *StrongParamsRequiredAndPermitted {
+	return this.withCrossFieldRule(ExclusiveRule, keys)
+}
+
+// DependsOn instructs to require all the `dependencies` to be present in the permitted parameters if the `key` is
+// present, eg. "end_date" requiring "start_date". Equivalent to the permit rule "dependsOn(key, dependency)".
+func (this *StrongParamsRequiredAndPermitted) DependsOn(key string,
+	dependencies ...string) *StrongParamsRequiredAndPermitted {
+
+	return this.withCrossFieldRule(DependsOnRule, append([]string{key}, dependencies...))
+}
+
+func (this *StrongParamsRequiredAndPermitted) withCrossFieldRule(kind CrossFieldKind,
+	keys []string) *StrongParamsRequiredAndPermitted {
+
+	params := *this.strongParamsRequiredAndPermitted
+	params.crossFieldRules = append([]crossFieldRule{}, this.crossFieldRules...)
+
+	if params.error == nil {
+		rule, err := newCrossFieldRule(kind, keys)
+		if err != nil {
+			required := *params.strongParamsRequired
+			required.error = err
+			params.strongParamsRequired = &required
+		}
+		params.crossFieldRules = append(params.crossFieldRules, rule)
+	}
+
+	return &StrongParamsRequiredAndPermitted{&params}
+}
+
+// validateCrossFields verifies the permitted `values` by the cross-field rules.
+func (this *strongParamsRequiredAndPermitted) validateCrossFields(values url.Values) error {
+	var crossFieldErrors CrossFieldErrors
+	for _, rule := range this.crossFieldRules {
+		if err := rule.verify(values, this.untransformKey); err != nil {
+			crossFieldErrors = append(crossFieldErrors, err)
+		}
+	}
+
+	if len(crossFieldErrors) > 0 {
+		return crossFieldErrors
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package strongparams
+
+import (
+	"github.com/pkg/errors"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var rgxDefault = regexp.MustCompile(
+	"(?:^|[\\s,{\\[])(?P<Key>'[%\\w -]+'|[%\\w-]+)\\s*=\\s*(?P<Value>'[^']*'|[^\\s,{}\\[\\]()']*)")
+
+// extractDefaults returns the `permitRule` with the default values declared at the top level of the rule, eg.
+// "page=1, per_page=25", replaced by the keys and the extracted default values.
+func extractDefaults(permitRule string) (string, url.Values, error) {
+	defaults := url.Values{}
+	rule := permitRule
+
+	for _, defaultResult := range rgxDefault.FindAllStringSubmatchIndex(permitRule, -1) {
+		if !isTopLevel(permitRule[:defaultResult[0]+1]) {
+			return "", nil, errors.Errorf("defaults: default values are allowed only at the top level of rule `%s`",
+				permitRule)
+		}
+
+		keyIdx := 2 * rgxDefault.SubexpIndex("Key")
+		valueIdx := 2 * rgxDefault.SubexpIndex("Value")
+		key := permitRule[defaultResult[keyIdx]:defaultResult[keyIdx+1]]
+		value := permitRule[defaultResult[valueIdx]:defaultResult[valueIdx+1]]
+		if value == "" {
+			return "", nil, errors.Errorf("defaults: empty default value of key `%s`", key)
+		}
+
+		defaults.Add(strings.Trim(key, "'"), strings.Trim(value, "'"))
+		rule = strings.Replace(rule, permitRule[defaultResult[keyIdx]:defaultResult[valueIdx+1]], key, 1)
+	}
+
+	return rule, defaults, nil
+}
+
+// Default instructs to inject the `value` and the `values` of the `key` into the permitted parameters if the key is
+// missing or holds only empty values. The key is relative to the required key as the permit rules are and it has to be
+// permitted by the rules. The values of the keys permitted only as arrays, eg. by "status:[]", are injected as the
+// array values, ie. by "status[]". Equivalent to the permit rule "key=value" of the scalar keys.
+//   Params().Permit("page, per_page, sort").Default("per_page", "25").Default("sort", "created_at").
+//       Query(request)(&list)
+func (this *StrongParamsRequiredAndPermitted) Default(key string, value string,
+	values ...string) *StrongParamsRequiredAndPermitted {
+
+	params := *this.strongParamsRequiredAndPermitted
+	params.defaults = cloneUrlValues(this.defaults)
+
+	if params.error != nil {
+		return &StrongParamsRequiredAndPermitted{&params}
+	}
+
+	defaultKey, err := params.defaultKey(key)
+	if err != nil {
+		required := *params.strongParamsRequired
+		required.error = err
+		params.strongParamsRequired = &required
+	}
+	params.defaults[defaultKey] = append([]string{value}, values...)
+
+	return &StrongParamsRequiredAndPermitted{&params}
+}
+
+// defaultKey returns the key the default values of the `key` are injected by. The keys permitted only as arrays are
+// returned in the array form "key[]" and the keys not permitted by the rules produce an error.
+func (this *strongParamsRequiredAndPermitted) defaultKey(key string) (string, error) {
+	switch {
+	case key == "":
+		return key, errors.New("defaults: default key cannot be empty")
+	case this.permitRules.IsPermitted(key):
+		return key, nil
+	case this.permitRules.IsPermitted(key + "[]"):
+		return key + "[]", nil
+	}
+	return key, errors.Errorf("defaults: default key `%s` is not permitted", key)
+}
+
+// withDefaults injects the default values into the permitted `values` of the keys which are missing or hold only empty
+// values. The empty values of the keys and their nested keys are replaced.
+func (this *strongParamsRequiredAndPermitted) withDefaults(values url.Values) url.Values {
+	for key, defaultValues := range this.defaults {
+		baseKey := strings.TrimSuffix(key, "[]")
+		if isKeyPresent(values, baseKey) {
+			continue
+		}
+		for valueKey := range values {
+			if isKeyUnder(strings.TrimSuffix(valueKey, "[]"), baseKey) {
+				delete(values, valueKey)
+			}
+		}
+		values[key] = append([]string{}, defaultValues...)
+	}
+	return values
+}
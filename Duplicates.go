@@ -0,0 +1,113 @@
+package strongparams
+
+import (
+	"fmt"
+	"github.com/vellotis/go-strongparams/internal/types"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// DuplicatePolicy declares how the repeated values of a key decoded into a scalar struct field are resolved, eg.
+// "name=a&name=b".
+type DuplicatePolicy int
+
+const (
+	// DuplicateDefault leaves the repeated values to the decoder. It is the default policy: the struct fields are decoded
+	// from the last value by schema.Decoder and StrongParams.RequireOne uses the first value.
+	DuplicateDefault DuplicatePolicy = iota
+	// DuplicateFirst keeps the first value.
+	DuplicateFirst
+	// DuplicateLast keeps the last value.
+	DuplicateLast
+	// DuplicateError produces a DuplicateKeysError naming the repeated keys.
+	DuplicateError
+	// DuplicateJoin joins the values with a comma, eg. "a,b".
+	DuplicateJoin
+)
+
+// WithDuplicatePolicy declares how the repeated values of the keys decoded into the scalar struct fields and the
+// StrongParams.RequireOne values are resolved. The policy will be used on the returned *StrongParams struct pointer not
+// on the receiver parameter. The default is DuplicateDefault which leaves the values to schema.Decoder decoding the
+// struct fields from the last value.
+//   Go: Params().WithDuplicatePolicy(DuplicateError).Permit("name, tags:[]")
+//   Query: name=a&name=b&tags[]=x&tags[]=y
+//   Error: duplicate values of scalar keys: `name`
+// The keys of the slice fields and the Tree targets keep all the values.
+func (this *StrongParams) WithDuplicatePolicy(policy DuplicatePolicy) *StrongParams {
+	params := this.clone()
+	params.duplicatePolicy = policy
+	return params
+}
+
+// DuplicateKeysError is produced by the DuplicateError policy. The Keys are the repeated keys in the brackets notation.
+type DuplicateKeysError struct {
+	Keys []string
+}
+
+func (this *DuplicateKeysError) Error() string {
+	keys := make([]string, len(this.Keys))
+	for idx, key := range this.Keys {
+		keys[idx] = fmt.Sprintf("`%s`", key)
+	}
+	return "duplicate values of scalar keys: " + strings.Join(keys, ", ")
+}
+
+// resolve returns the single value of the repeated `values` or false if the policy rejects them. The DuplicateDefault
+// policy returns the `values` as is.
+func (this DuplicatePolicy) resolve(values []string) ([]string, bool) {
+	if len(values) < 2 {
+		return values, true
+	}
+
+	switch this {
+	case DuplicateDefault:
+		return values, true
+	case DuplicateLast:
+		return values[len(values)-1:], true
+	case DuplicateError:
+		return values, false
+	case DuplicateJoin:
+		return []string{strings.Join(values, ",")}, true
+	}
+	return values[:1], true
+}
+
+// resolveDuplicates resolves the repeated values of the dot notation `values` keys addressing the scalar fields of the
+// `target` struct.
+func (this DuplicatePolicy) resolveDuplicates(values map[string][]string, target interface{}, aliasTag string) error {
+	if this == DuplicateDefault {
+		return nil
+	}
+
+	var rejected []string
+	for key, value := range values {
+		if len(value) < 2 || !isScalarField(reflect.TypeOf(target), strings.Split(key, "."), aliasTag) {
+			continue
+		}
+		if resolved, ok := this.resolve(value); ok {
+			values[key] = resolved
+		} else {
+			rejected = append(rejected, joinSegments(strings.Split(key, ".")))
+		}
+	}
+
+	if len(rejected) > 0 {
+		sort.Strings(rejected)
+		return &DuplicateKeysError{Keys: rejected}
+	}
+	return nil
+}
+
+// isScalarField returns whether the field of the `targetType` addressed by the `segments` is decoded from a single
+// value. Look types.IsScalar for the details.
+func isScalarField(targetType reflect.Type, segments []string, aliasTag string) bool {
+	fieldType, ok := fieldTypeByKey(targetType, segments, aliasTag)
+	if !ok {
+		return false
+	}
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	return types.IsScalar(fieldType)
+}
@@ -0,0 +1,168 @@
+package strongparams
+
+import (
+	"github.com/gorilla/schema"
+	"github.com/thoas/go-funk"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+const (
+	// HeaderTag is the struct tag the header parameters are decoded by, eg. `header:"X-Tenant-Id"`.
+	HeaderTag = "header"
+	// CookieTag is the struct tag the cookie parameters are decoded by, eg. `cookie:"session"`.
+	CookieTag = "cookie"
+)
+
+// Headers returns the Source of the http.Request's headers named "header". The keys are the canonical header names as
+// returned by http.CanonicalHeaderKey, eg. "X-Tenant-Id", and the struct fields are resolved from the HeaderTag tag.
+func Headers(request *http.Request) Source {
+	return &namedSource{
+		name: "header",
+		loader: func(*strongParams, func(string) bool) *sourceValues {
+			return newHeaderSourceValues(request)
+		},
+	}
+}
+
+// Cookies returns the Source of the http.Request's cookies named "cookie". The keys are the cookie names and the struct
+// fields are resolved from the CookieTag tag.
+func Cookies(request *http.Request) Source {
+	return &namedSource{
+		name: "cookie",
+		loader: func(*strongParams, func(string) bool) *sourceValues {
+			return newCookieSourceValues(request)
+		},
+	}
+}
+
+func newHeaderSourceValues(request *http.Request) *sourceValues {
+	values := url.Values{}
+	for key, value := range request.Header {
+		canonicalKey := http.CanonicalHeaderKey(key)
+		values[canonicalKey] = append(values[canonicalKey], value...)
+	}
+	return &sourceValues{
+		values:   values,
+		aliasTag: HeaderTag,
+	}
+}
+
+func newCookieSourceValues(request *http.Request) *sourceValues {
+	values := url.Values{}
+	for _, cookie := range request.Cookies() {
+		values.Add(cookie.Name, cookie.Value)
+	}
+	return &sourceValues{
+		values:   values,
+		aliasTag: CookieTag,
+	}
+}
+
+// retagged returns the `values` and the `origins` of a source which struct fields are resolved from the dedicated
+// `sourceTag` tag with the keys of the `target` struct fields resolved from the `aliasTag` tag of the decoder. This
+// enables decoding the source with the configured decoder. As done by schema.Decoder, the keys match the tags or the
+// field names case-insensitively. The keys without a field are dropped as the requests contain headers and cookies not
+// meant to be decoded. The fields declared with the "required" option of the `sourceTag` tag are verified.
+func retagged(values url.Values, origins map[string]string, target interface{}, sourceTag string,
+	aliasTag string) (url.Values, map[string]string, error) {
+
+	targetType := reflect.TypeOf(target)
+	for targetType.Kind() == reflect.Ptr {
+		targetType = targetType.Elem()
+	}
+	if targetType.Kind() != reflect.Struct {
+		return values, origins, nil
+	}
+
+	retaggedValues, retaggedOrigins := url.Values{}, map[string]string{}
+	for key, value := range values {
+		fieldKey, ok := fieldKeyByTag(targetType, key, sourceTag, aliasTag)
+		if !ok {
+			continue
+		}
+		retaggedValues[fieldKey] = append(retaggedValues[fieldKey], value...)
+		if origin, ok := origins[key]; ok {
+			retaggedOrigins[fieldKey] = origin
+		}
+	}
+
+	missing := schema.MultiError{}
+	for _, requiredKey := range requiredTagKeys(targetType, sourceTag) {
+		found := false
+		for key := range values {
+			found = found || strings.EqualFold(key, requiredKey)
+		}
+		if !found {
+			missing[requiredKey] = schema.EmptyFieldError{Key: requiredKey}
+		}
+	}
+	if len(missing) > 0 {
+		return nil, nil, missing
+	}
+
+	return retaggedValues, retaggedOrigins, nil
+}
+
+// fieldKeyByTag returns the key resolved from the `aliasTag` tag of the `structType` field which `sourceTag` tag or
+// name matches the `key`. The fields of the embedded structs without an explicit key are promoted.
+func fieldKeyByTag(structType reflect.Type, key string, sourceTag string, aliasTag string) (string, bool) {
+	for idx := 0; idx < structType.NumField(); idx++ {
+		field := structType.Field(idx)
+		tag := strings.Split(field.Tag.Get(sourceTag), ",")[0]
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		if field.Anonymous && tag == "" && fieldType.Kind() == reflect.Struct {
+			if subKey, ok := fieldKeyByTag(fieldType, key, sourceTag, aliasTag); ok {
+				if embeddedKey, ok := fieldKey(field, aliasTag); ok {
+					return embeddedKey + "." + subKey, true
+				}
+				return subKey, true
+			}
+		} else if field.PkgPath == "" && tag != "-" && (strings.EqualFold(tag, key) ||
+			(tag == "" && strings.EqualFold(field.Name, key))) {
+			if strings.Split(field.Tag.Get(aliasTag), ",")[0] == "-" {
+				return "", false
+			}
+			return fieldKey(field, aliasTag)
+		}
+	}
+	return "", false
+}
+
+// requiredTagKeys returns the keys of the `structType` fields declared with the "required" option of the `sourceTag`
+// tag, eg. `header:"X-Tenant-Id,required"`.
+func requiredTagKeys(structType reflect.Type, sourceTag string) []string {
+	var keys []string
+	for idx := 0; idx < structType.NumField(); idx++ {
+		options := strings.Split(structType.Field(idx).Tag.Get(sourceTag), ",")
+		if options[0] != "" && options[0] != "-" && funk.ContainsString(options[1:], "required") {
+			keys = append(keys, options[0])
+		}
+	}
+	return keys
+}
+
+// Headers instructs the mechanism to process the http.Request's headers. The keys are the canonical header names and
+// the target struct fields are resolved from the HeaderTag tag:
+//   type APIHeaders struct {
+//       Version  int    `header:"X-Api-Version"`
+//       TenantID string `header:"X-Tenant-Id"`
+//   }
+//   Params().Permit("X-Api-Version, X-Tenant-Id").Headers(request)(&headers)
+// The headers are decoded by the declared decoder ignoring the unknown keys.
+func (this *StrongParams) Headers(request *http.Request) ReturnTarget {
+	return this.source(newHeaderSourceValues(request))
+}
+
+// Cookies instructs the mechanism to process the http.Request's cookies. The target struct fields are resolved from the
+// CookieTag tag, eg. `cookie:"session"`. The cookies are decoded by the declared decoder ignoring the unknown keys.
+func (this *StrongParams) Cookies(request *http.Request) ReturnTarget {
+	return this.source(newCookieSourceValues(request))
+}
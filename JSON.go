@@ -0,0 +1,98 @@
+package strongparams
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// newJSONSourceValues parses the `request` JSON body. The body exceeding the MaxBodyBytes of the `limits` produces a
+// LimitError.
+func newJSONSourceValues(request *http.Request, limits RequestLimits) *sourceValues {
+	if request.Body == nil || request.Body == http.NoBody {
+		return newSourceError(errors.New("json: request body is empty"))
+	}
+
+	body, err := readLimited(request.Body, limits.withDefaults().MaxBodyBytes)
+	if err != nil {
+		return newSourceError(err)
+	}
+	return newJSONReaderSourceValues(bytes.NewReader(body))
+}
+
+func newJSONReaderSourceValues(reader io.Reader) *sourceValues {
+	decoder := json.NewDecoder(reader)
+	decoder.UseNumber()
+
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return newSourceError(errors.Wrap(err, "json: failed to parse request body"))
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return newSourceError(errors.New("json: unexpected data after the JSON document"))
+	}
+
+	object, ok := document.(map[string]interface{})
+	if !ok {
+		return newSourceError(errors.New("json: request body must be a JSON object"))
+	}
+
+	source := &sourceValues{
+		values: url.Values{},
+		typed:  map[string][]interface{}{},
+	}
+	for key, value := range object {
+		source.flattenJSON(key, value)
+	}
+	return source
+}
+
+// flattenJSON adds the JSON `value` to the source values by the brackets notation `key`. Arrays of values are flattened
+// to "key[]" keys and arrays containing objects or arrays are flattened to indexed "key[0]" keys.
+func (this *sourceValues) flattenJSON(key string, value interface{}) {
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		for subKey, subValue := range typedValue {
+			this.flattenJSON(joinKey(key, subKey), subValue)
+		}
+
+	case []interface{}:
+		if isJSONValueArray(typedValue) {
+			for _, elem := range typedValue {
+				this.addTyped(key+"[]", elem)
+			}
+			return
+		}
+		for idx, elem := range typedValue {
+			this.flattenJSON(joinKey(key, strconv.Itoa(idx)), elem)
+		}
+
+	default:
+		this.addTyped(key, typedValue)
+	}
+}
+
+func (this *sourceValues) addTyped(key string, value interface{}) {
+	this.typed[key] = append(this.typed[key], value)
+	if value == nil {
+		if _, ok := this.values[key]; !ok {
+			this.values[key] = []string{}
+		}
+		return
+	}
+	this.values[key] = append(this.values[key], stringifyTyped(value))
+}
+
+func isJSONValueArray(array []interface{}) bool {
+	for _, elem := range array {
+		switch elem.(type) {
+		case map[string]interface{}, []interface{}:
+			return false
+		}
+	}
+	return true
+}
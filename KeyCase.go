@@ -0,0 +1,160 @@
+package strongparams
+
+import (
+	"github.com/pkg/errors"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// KeyCase declares the case the key segments of the processed parameters are converted to.
+type KeyCase int
+
+const (
+	// KeepCase keeps the keys as is. It is the default.
+	KeepCase KeyCase = iota
+	// SnakeCase converts the key segments to the snake_case, eg. "firstName" to "first_name".
+	SnakeCase
+	// CamelCase converts the key segments to the camelCase, eg. "first_name" to "firstName".
+	CamelCase
+	// KebabCase converts the key segments to the kebab-case, eg. "firstName" to "first-name".
+	KebabCase
+)
+
+// WithKeyCase declares the case each segment of the keys is converted to before applying Require and Permit. The
+// rules and the struct tags are declared in the `keyCase` case. The case will be used on the returned *StrongParams
+// struct pointer not on the receiver parameter.
+//   Go: Params().WithKeyCase(SnakeCase).Require("user").Permit("first_name, home_address:{zip_code}")
+//   Query: user[firstName]=John&user[homeAddress][zipCode]=10115
+//   Processed as: user[first_name]=John&user[home_address][zip_code]=10115
+// The numeric segments and the leading underscores, eg. "_destroy", are kept. The keys converting to the same key, eg.
+// "firstName" and "first_name", produce an error. The keys of the sources with a dedicated struct tag, eg. Headers,
+// are not converted.
+func (this *StrongParams) WithKeyCase(keyCase KeyCase) *StrongParams {
+	params := this.clone()
+	params.keyCase = keyCase
+	return params
+}
+
+// convert converts each segment of the bracket notation `key` to the case.
+func (this KeyCase) convert(key string) string {
+	if this == KeepCase {
+		return key
+	}
+
+	segments := splitKey(key)
+	if len(segments) == 1 && strings.ContainsAny(key, "[]") {
+		return key
+	}
+	for idx, segment := range segments {
+		if !rgxCollectionIndex.MatchString(segment) {
+			segments[idx] = this.convertSegment(segment)
+		}
+	}
+	return joinSegments(segments)
+}
+
+func (this KeyCase) convertSegment(segment string) string {
+	body := strings.TrimLeft(segment, "_-")
+	prefix := segment[:len(segment)-len(body)]
+
+	words := keyWords(body)
+	for idx, word := range words {
+		word = strings.ToLower(word)
+		if this == CamelCase && idx > 0 {
+			runes := []rune(word)
+			runes[0] = unicode.ToUpper(runes[0])
+			word = string(runes)
+		}
+		words[idx] = word
+	}
+
+	switch this {
+	case SnakeCase:
+		return prefix + strings.Join(words, "_")
+	case KebabCase:
+		return prefix + strings.Join(words, "-")
+	}
+	return prefix + strings.Join(words, "")
+}
+
+// keyWords splits the `segment` to the words separated by the underscores, the dashes and the case boundaries, eg.
+// "userID", "HTTPServer" and "address2Line" result in ["user", "ID"], ["HTTP", "Server"] and ["address2", "Line"].
+func keyWords(segment string) []string {
+	var words []string
+	runes := []rune(segment)
+	start := 0
+	for idx, r := range runes {
+		if r == '_' || r == '-' {
+			if idx > start {
+				words = append(words, string(runes[start:idx]))
+			}
+			start = idx + 1
+			continue
+		}
+
+		if idx > start && unicode.IsUpper(r) {
+			previous := runes[idx-1]
+			isNextLower := idx+1 < len(runes) && unicode.IsLower(runes[idx+1])
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && isNextLower) {
+				words = append(words, string(runes[start:idx]))
+				start = idx
+			}
+		}
+	}
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// keyCased returns the sourceValues with the keys converted to the key case or an error if multiple keys convert to
+// the same key.
+func (this *strongParams) keyCased(source *sourceValues) *sourceValues {
+	if this.keyCase == KeepCase || source.error != nil || source.aliasTag != "" {
+		return source
+	}
+
+	keys := make([]string, 0, len(source.values))
+	for key := range source.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	rename := map[string]string{}
+	converted := map[string]string{}
+	for _, key := range keys {
+		convertedKey := this.keyCase.convert(key)
+		if original, ok := converted[convertedKey]; ok {
+			return newSourceError(errors.Errorf("key case: keys `%s` and `%s` convert to the same key `%s`",
+				original, key, convertedKey))
+		}
+		converted[convertedKey] = key
+		if convertedKey != key {
+			rename[key] = convertedKey
+		}
+	}
+
+	return source.renamed(rename)
+}
+
+// sourceKey returns the source `key` as processed by Require and Permit.
+func (this *strongParams) sourceKey(key string) string {
+	return this.keyCase.convert(this.notation.bracketKey(key))
+}
+
+// prepared returns the sourceValues with the keys converted by the notation and the key case and checked by the
+// limits. The errors of the declarations of the options are returned as the source errors and the temporary files of
+// the failed sources are removed.
+func (this *strongParams) prepared(source *sourceValues) *sourceValues {
+	if this.configError != nil {
+		source.removeFiles()
+		return newSourceError(this.configError)
+	}
+
+	prepared := this.limited(this.keyCased(this.notated(source)))
+	if prepared.error != nil {
+		source.removeFiles()
+	}
+	return prepared
+}
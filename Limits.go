@@ -0,0 +1,154 @@
+package strongparams
+
+import (
+	"sort"
+	"strconv"
+)
+
+// Limits declares the size and the shape limits of the processed parameters. The limits are enforced on all the keys
+// of the source before applying Require and Permit. The zero value fields fall back to the DefaultLimits values and the
+// negative values disable the limit.
+type Limits struct {
+	// MaxKeys limits the number of the distinct keys.
+	MaxKeys int
+	// MaxDepth limits the number of the segments of a key, eg. "user[address][city]" has 3 segments.
+	MaxDepth int
+	// MaxArrayIndex limits the value of the numeric segments, eg. "tags[99999999]".
+	MaxArrayIndex int
+	// MaxArrayLen limits the number of the elements of an array, ie. the distinct indexes and the values of the "key[]"
+	// keys, and the number of the values of a single key.
+	MaxArrayLen int
+	// MaxValueBytes limits the size of a single value.
+	MaxValueBytes int
+	// MaxKeyBytes limits the size of a single key.
+	MaxKeyBytes int
+}
+
+// DefaultLimits holds the limits used for the zero value fields of Limits.
+var DefaultLimits = Limits{
+	MaxKeys:       1000,
+	MaxDepth:      32,
+	MaxArrayIndex: 1000,
+	MaxArrayLen:   1000,
+	MaxValueBytes: 1 << 20,
+	MaxKeyBytes:   1 << 10,
+}
+
+func (this Limits) withDefaults() Limits {
+	if this.MaxKeys == 0 {
+		this.MaxKeys = DefaultLimits.MaxKeys
+	}
+	if this.MaxDepth == 0 {
+		this.MaxDepth = DefaultLimits.MaxDepth
+	}
+	if this.MaxArrayIndex == 0 {
+		this.MaxArrayIndex = DefaultLimits.MaxArrayIndex
+	}
+	if this.MaxArrayLen == 0 {
+		this.MaxArrayLen = DefaultLimits.MaxArrayLen
+	}
+	if this.MaxValueBytes == 0 {
+		this.MaxValueBytes = DefaultLimits.MaxValueBytes
+	}
+	if this.MaxKeyBytes == 0 {
+		this.MaxKeyBytes = DefaultLimits.MaxKeyBytes
+	}
+	return this
+}
+
+// WithLimits declares the size and the shape limits of the processed parameters. The limits will be used on the
+// returned *StrongParams struct pointer not on the receiver parameter. Without the declaration DefaultLimits are
+// enforced.
+//   Go: Params().WithLimits(Limits{MaxArrayIndex: 100}).Require("user").Permit("tags:[]")
+//   Query: user[tags][99999999]=x
+//   Error: limit `MaxArrayIndex` of 100 exceeded by key `user[tags][99999999]`
+// The exceeded limits produce a LimitError. MaxArrayIndex is not enforced if StrongParams.WithCollectionNormalization
+// is declared as the sparse indexes are normalized into dense slices.
+func (this *StrongParams) WithLimits(limits Limits) *StrongParams {
+	params := this.clone()
+	params.limits = limits
+	return params
+}
+
+// limited returns the sourceValues with the LimitError of the first key exceeding the limits.
+func (this *strongParams) limited(source *sourceValues) *sourceValues {
+	if source.error != nil {
+		return source
+	}
+	limits := this.limits.withDefaults()
+	if this.normalizeCollections {
+		// The sparse indexes do not allocate the slices when normalized
+		limits.MaxArrayIndex = -1
+	}
+	if err := limits.check(source.values); err != nil {
+		return newSourceError(err)
+	}
+	return source
+}
+
+// check returns the LimitError of the first of the sorted `values` keys exceeding the limits.
+func (this Limits) check(values map[string][]string) error {
+	if exceeds(len(values), this.MaxKeys) {
+		return &LimitError{Limit: "MaxKeys", Max: int64(this.MaxKeys)}
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	lengths := map[string]int{}
+	elements := map[string]map[string]bool{}
+	for _, key := range keys {
+		if exceeds(len(key), this.MaxKeyBytes) {
+			return &LimitError{Limit: "MaxKeyBytes", Key: key, Max: int64(this.MaxKeyBytes)}
+		}
+		for _, value := range values[key] {
+			if exceeds(len(value), this.MaxValueBytes) {
+				return &LimitError{Limit: "MaxValueBytes", Key: key, Max: int64(this.MaxValueBytes)}
+			}
+		}
+
+		segments := splitKey(key)
+		if exceeds(len(segments), this.MaxDepth) {
+			return &LimitError{Limit: "MaxDepth", Key: key, Max: int64(this.MaxDepth)}
+		}
+
+		for idx := 1; idx < len(segments); idx++ {
+			if !rgxCollectionIndex.MatchString(segments[idx]) {
+				continue
+			} else if this.MaxArrayIndex >= 0 {
+				if index, err := strconv.Atoi(segments[idx]); err != nil || index > this.MaxArrayIndex {
+					return &LimitError{Limit: "MaxArrayIndex", Key: key, Max: int64(this.MaxArrayIndex)}
+				}
+			}
+
+			collection := joinSegments(segments[:idx])
+			if elements[collection] == nil {
+				elements[collection] = map[string]bool{}
+			}
+			elements[collection][segments[idx]] = true
+			if exceeds(lengths[collection]+len(elements[collection]), this.MaxArrayLen) {
+				return &LimitError{Limit: "MaxArrayLen", Key: collection, Max: int64(this.MaxArrayLen)}
+			}
+		}
+
+		// The values of "key[]" and "key" are the elements of the same array
+		collection := key
+		if last := len(segments) - 1; last > 0 && segments[last] == "" {
+			collection = joinSegments(segments[:last])
+		}
+		lengths[collection] += len(values[key])
+		if exceeds(lengths[collection]+len(elements[collection]), this.MaxArrayLen) {
+			return &LimitError{Limit: "MaxArrayLen", Key: collection, Max: int64(this.MaxArrayLen)}
+		}
+	}
+
+	return nil
+}
+
+// exceeds returns whether the `value` exceeds the `max` limit. The negative limits are disabled.
+func exceeds(value int, max int) bool {
+	return max >= 0 && value > max
+}
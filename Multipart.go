@@ -0,0 +1,295 @@
+package strongparams
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"reflect"
+)
+
+// MultipartLimits declares the size limits of the streamed multipart/form-data parts. The zero value fields fall back
+// to the DefaultMultipartLimits values and the negative values disable the limit.
+type MultipartLimits struct {
+	// MaxFieldBytes limits the size of a single non-file field value.
+	MaxFieldBytes int64
+	// MaxFileBytes limits the size of a single file.
+	MaxFileBytes int64
+	// MaxTotalBytes limits the total size of the permitted parts.
+	MaxTotalBytes int64
+	// MaxMemory limits the size of the files held in memory. The files not fitting into it are stored in temporary
+	// files which are removed after decoding. The temporary files of the files bound to the *multipart.FileHeader
+	// fields are removed when the request context is done and the ones bound to the io.Reader fields when closed.
+	MaxMemory int64
+}
+
+// DefaultMultipartLimits holds the limits used for the zero value fields of MultipartLimits.
+var DefaultMultipartLimits = MultipartLimits{
+	MaxFieldBytes: 1 << 20,
+	MaxFileBytes:  32 << 20,
+	MaxTotalBytes: 64 << 20,
+	MaxMemory:     32 << 20,
+}
+
+// LimitError is returned when the processed parameters exceed a configured limit.
+type LimitError struct {
+	// Limit is the name of the exceeded limit, eg. "MaxFileBytes".
+	Limit string
+	// Key is the key of the parameter exceeding the limit. It is empty for the limits not related to a single key.
+	Key string
+	Max int64
+}
+
+func (this *LimitError) Error() string {
+	if this.Key == "" {
+		return fmt.Sprintf("limit `%s` of %d exceeded", this.Limit, this.Max)
+	}
+	return fmt.Sprintf("limit `%s` of %d exceeded by key `%s`", this.Limit, this.Max, this.Key)
+}
+
+var (
+	fileHeaderType      = reflect.TypeOf((*multipart.FileHeader)(nil))
+	fileHeaderSliceType = reflect.TypeOf([]*multipart.FileHeader(nil))
+	readerInterface     = reflect.TypeOf((*io.Reader)(nil)).Elem()
+)
+
+func (this MultipartLimits) withDefaults() MultipartLimits {
+	if this.MaxFieldBytes == 0 {
+		this.MaxFieldBytes = DefaultMultipartLimits.MaxFieldBytes
+	}
+	if this.MaxFileBytes == 0 {
+		this.MaxFileBytes = DefaultMultipartLimits.MaxFileBytes
+	}
+	if this.MaxTotalBytes == 0 {
+		this.MaxTotalBytes = DefaultMultipartLimits.MaxTotalBytes
+	}
+	if this.MaxMemory <= 0 {
+		this.MaxMemory = DefaultMultipartLimits.MaxMemory
+	}
+	return this
+}
+
+// newMultipartSourceValues streams the parts of the multipart/form-data request body. The parts which names are not
+// kept by `keep` are discarded before reading them. The Rack style arrays of objects are grouped by the order of the
+// parts. The files are read once, held in memory up to MaxMemory and stored in temporary files otherwise. The temporary
+// files are removed after decoding or when the request context is done.
+func newMultipartSourceValues(request *http.Request, limits MultipartLimits, keep func(key string) bool) *sourceValues {
+	reader, err := request.MultipartReader()
+	if err != nil {
+		return newSourceError(errors.Wrap(err, "multipart"))
+	}
+
+	limits = limits.withDefaults()
+	source := &sourceValues{
+		values: url.Values{},
+	}
+	remaining, memory := limits.MaxTotalBytes, limits.MaxMemory
+	grouper := newEmptyBracketGrouper()
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			source.removeFiles()
+			return newSourceError(errors.Wrap(err, "multipart"))
+		}
+
+		key := part.FormName()
+		if key == "" {
+			continue
+		}
+		// The discarded parts are grouped as well to resolve the Rack style arrays of objects as done by Rack
+		groupedKey := grouper.key(key)
+		if !keep(key) {
+			continue
+		}
+
+		if part.FileName() == "" {
+			value := &bytes.Buffer{}
+			read, err := copyLimited(value, part, key, "MaxFieldBytes", limits.MaxFieldBytes, remaining,
+				limits.MaxTotalBytes)
+			if err != nil {
+				source.removeFiles()
+				return newSourceError(err)
+			}
+			remaining -= read
+			source.values.Add(groupedKey, value.String())
+			continue
+		}
+
+		form, read, err := readFilePart(part, key, limits, remaining, memory)
+		if err != nil {
+			source.removeFiles()
+			return newSourceError(err)
+		}
+		remaining -= read
+		if read <= memory {
+			memory -= read
+		}
+		context.AfterFunc(request.Context(), func() {
+			_ = form.RemoveAll()
+		})
+
+		if source.files == nil {
+			source.files = map[string][]*multipart.FileHeader{}
+			source.fileForms = map[*multipart.FileHeader]*multipart.Form{}
+		}
+		for _, fileHeader := range form.File[key] {
+			source.files[groupedKey] = append(source.files[groupedKey], fileHeader)
+			source.fileForms[fileHeader] = form
+		}
+		if _, ok := source.values[groupedKey]; !ok {
+			source.values[groupedKey] = []string{}
+		}
+	}
+
+	return source
+}
+
+// readFilePart reads the file `part` into a multipart.Form holding the single file. The file is held in memory if it
+// fits into `maxMemory` and stored in a temporary file otherwise as done by multipart.Reader.ReadForm. The part is
+// streamed through a pipe so it is read only once.
+func readFilePart(part *multipart.Part, key string, limits MultipartLimits, remaining int64,
+	maxMemory int64) (*multipart.Form, int64, error) {
+
+	pipeReader, pipeWriter := io.Pipe()
+	writer := multipart.NewWriter(pipeWriter)
+	copied := make(chan error, 1)
+	var read int64
+
+	go func() {
+		fileWriter, err := writer.CreatePart(part.Header)
+		if err == nil {
+			read, err = copyLimited(fileWriter, part, key, "MaxFileBytes", limits.MaxFileBytes, remaining,
+				limits.MaxTotalBytes)
+		}
+		if err == nil {
+			err = writer.Close()
+		}
+		pipeWriter.CloseWithError(err)
+		copied <- err
+	}()
+
+	form, err := multipart.NewReader(pipeReader, writer.Boundary()).ReadForm(maxMemory)
+	_ = pipeReader.Close()
+	if copyErr := <-copied; copyErr != nil || err != nil {
+		if form != nil {
+			_ = form.RemoveAll()
+		}
+		if copyErr != nil {
+			return nil, 0, copyErr
+		}
+		return nil, 0, errors.Wrap(err, "multipart")
+	}
+	return form, read, nil
+}
+
+// removeFiles removes the temporary files of the files. The forms are shared by the sourceValues derived from the
+// source so the files bound to the target are dropped from them by bindFiles before.
+func (this *sourceValues) removeFiles() {
+	for fileHeader, form := range this.fileForms {
+		_ = form.RemoveAll()
+		delete(this.fileForms, fileHeader)
+	}
+}
+
+// removingFile is the multipart.File bound to an io.Reader field which removes its temporary file when closed.
+type removingFile struct {
+	multipart.File
+	form *multipart.Form
+}
+
+func (this *removingFile) Close() error {
+	err := this.File.Close()
+	_ = this.form.RemoveAll()
+	return err
+}
+
+// copyLimited copies from `reader` to `writer` until EOF or returns a *LimitError if either the part limit `max` or
+// the remaining total limit is exceeded. The negative limits are not enforced.
+func copyLimited(writer io.Writer, reader io.Reader, key string, limit string, max int64, remaining int64,
+	maxTotal int64) (int64, error) {
+
+	allowed, exceededLimit, exceededMax := max, limit, max
+	if maxTotal >= 0 && (max < 0 || remaining < max) {
+		allowed, exceededLimit, exceededMax = remaining, "MaxTotalBytes", maxTotal
+	}
+	if allowed < 0 {
+		return io.Copy(writer, reader)
+	}
+
+	read, err := io.Copy(writer, io.LimitReader(reader, allowed+1))
+	if err != nil {
+		return read, err
+	} else if read > allowed {
+		return read, &LimitError{Limit: exceededLimit, Key: key, Max: exceededMax}
+	}
+	return read, nil
+}
+
+// bindFiles sets the files to the `target` struct fields resolved by the file keys. Supported field types are
+// *multipart.FileHeader, []*multipart.FileHeader and io.Reader.
+// The temporary files of the bound files are kept by dropping them from the forms removed by sourceValues.removeFiles.
+func (this *strongParams) bindFiles(source *sourceValues, target interface{}) error {
+	for key, fileHeaders := range source.files {
+		if len(fileHeaders) == 0 {
+			continue
+		}
+
+		field, ok := fieldByKey(reflect.ValueOf(target), splitKey(key), this.aliasTag)
+		if !ok {
+			return errors.Errorf("multipart: no field for file key `%s`", key)
+		}
+
+		switch {
+		case field.Type() == fileHeaderType:
+			field.Set(reflect.ValueOf(fileHeaders[0]))
+			delete(source.fileForms, fileHeaders[0])
+
+		case field.Type() == fileHeaderSliceType:
+			field.Set(reflect.ValueOf(fileHeaders))
+			for _, fileHeader := range fileHeaders {
+				delete(source.fileForms, fileHeader)
+			}
+
+		case field.Type() == readerInterface:
+			file, err := fileHeaders[0].Open()
+			if err != nil {
+				return errors.Wrapf(err, "multipart: failed to open file of key `%s`", key)
+			}
+			if form, ok := source.fileForms[fileHeaders[0]]; ok {
+				file = &removingFile{File: file, form: form}
+				delete(source.fileForms, fileHeaders[0])
+			}
+			field.Set(reflect.ValueOf(file))
+
+		default:
+			return errors.Errorf("multipart: field of file key `%s` must be of type *multipart.FileHeader, "+
+				"[]*multipart.FileHeader or io.Reader, got `%s`", key, field.Type())
+		}
+	}
+
+	return nil
+}
+
+// Multipart instructs the mechanism to stream the http.Request's multipart/form-data body instead of parsing it with
+// http.Request.ParseMultipartForm. The parts which names are not permitted are discarded before reading them and the
+// `limits` size limits are enforced on the rest. The permitted file parts are bound to the target struct fields of
+// type *multipart.FileHeader, []*multipart.FileHeader or io.Reader. The io.Reader fields receive the opened
+// multipart.File which should be closed by the caller. Look MultipartLimits.MaxMemory for the temporary files.
+//   type Avatar struct {
+//       Name string                `params:"name"`
+//       File *multipart.FileHeader `params:"file"`
+//   }
+//   Params().Require("avatar").Permit("name, file").Multipart(request, MultipartLimits{MaxFileBytes: 1 << 20})(&avatar)
+// The files are bound to struct targets only.
+func (this *StrongParams) Multipart(request *http.Request, limits MultipartLimits) ReturnTarget {
+	return this.source(newMultipartSourceValues(request, limits, func(string) bool {
+		return true
+	}))
+}
@@ -0,0 +1,105 @@
+package strongparams
+
+import (
+	"strings"
+)
+
+// Notation declares the notation of the nested keys of the processed parameters.
+type Notation int
+
+const (
+	// Brackets declares the brackets notation, eg. "user[address][city]". The dots are literal characters of the key
+	// names. It is the default notation.
+	Brackets Notation = iota
+	// Dots declares the dot notation, eg. "user.address.city". The brackets of the dot notation keys are literal
+	// characters of the key names. The keys without dots are processed as brackets notation keys, eg. the keys of the
+	// JSON source or the "user[name]" key of a multipart form.
+	Dots
+	// Mixed declares that both the dots and the brackets separate the nested keys, eg. "user.address[city]".
+	Mixed
+)
+
+// WithNotation declares the notation of the nested keys of the processed parameters. The keys are converted to the
+// brackets notation before applying Require and Permit. The notation will be used on the returned *StrongParams struct
+// pointer not on the receiver parameter.
+//   Go: Params().WithNotation(Dots).Require("user").Permit("address:{city}")
+//   Query: user.address.city=Tallinn
+//   Processed as: user[address][city]=Tallinn
+// Independent of the notation the literal dots, brackets and percent signs of the key names can be percent-escaped as
+// "%2E", "%5B", "%5D" and "%25" inside the decoded keys, ie. double escaped in the query string. The permit rules match
+// the escaped names, eg. "file%2Ename", and the names are unescaped when decoding. As the struct targets are decoded
+// with the dot notation, the literal dots are only accepted by the Tree targets.
+func (this *StrongParams) WithNotation(notation Notation) *StrongParams {
+	params := this.clone()
+	params.notation = notation
+	return params
+}
+
+var keySegmentUnescaper = strings.NewReplacer(
+	"%25", "%",
+	"%2E", ".", "%2e", ".",
+	"%5B", "[", "%5b", "[",
+	"%5D", "]", "%5d", "]",
+)
+
+// unescapeKeySegments returns the `segments` with the percent-escaped literal characters unescaped.
+func unescapeKeySegments(segments []string) []string {
+	unescaped := make([]string, len(segments))
+	for idx, segment := range segments {
+		unescaped[idx] = keySegmentUnescaper.Replace(segment)
+	}
+	return unescaped
+}
+
+// bracketKey converts the `key` of the `notation` to the brackets notation. The literal characters of the segments
+// are escaped. The keys not following the notation are returned as is.
+func (this Notation) bracketKey(key string) string {
+	switch this {
+	case Dots:
+		if !strings.ContainsRune(key, '.') {
+			return key
+		}
+		segments := strings.Split(key, ".")
+		for idx, segment := range segments {
+			if segment == "" {
+				return key
+			}
+			segments[idx] = strings.NewReplacer("[", "%5B", "]", "%5D").Replace(segment)
+		}
+		return joinSegments(segments)
+
+	case Mixed:
+		if !strings.ContainsRune(key, '.') {
+			return key
+		}
+		var segments []string
+		for idx, part := range strings.Split(key, ".") {
+			partSegments := splitKey(part)
+			if part == "" || (idx > 0 && len(partSegments) > 1 && partSegments[0] == "") {
+				return key
+			}
+			if len(partSegments) == 1 && strings.ContainsAny(part, "[]") {
+				return key
+			}
+			segments = append(segments, partSegments...)
+		}
+		return joinSegments(segments)
+	}
+
+	return key
+}
+
+// notated returns the sourceValues with the keys converted from the notation to the brackets notation.
+func (this *strongParams) notated(source *sourceValues) *sourceValues {
+	if this.notation == Brackets || source.error != nil {
+		return source
+	}
+
+	rename := map[string]string{}
+	for key := range source.values {
+		if bracketKey := this.notation.bracketKey(key); bracketKey != key {
+			rename[key] = bracketKey
+		}
+	}
+	return source.renamed(rename)
+}
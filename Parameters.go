@@ -0,0 +1,218 @@
+package strongparams
+
+import (
+	"github.com/pkg/errors"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+// Parameters is an ActionController::Parameters inspired container of the request parameters. It tracks whether the
+// contained parameters have been whitelisted with Parameters.Permit which enables passing the sanitized parameters
+// through service layers instead of raw url.Values.
+//   params := NewParameters(request.URL.Query())
+//   user, err := params.Require("user")
+//   // handle error
+//   permitted, err := user.Permit("name, address:{street, city}")
+//   // handle error
+//   city := permitted.Dig("address", "city")
+type Parameters struct {
+	values    url.Values
+	permitted bool
+}
+
+// NewParameters creates unpermitted Parameters from the `values` parameter. The passed url.Values are cloned.
+func NewParameters(values url.Values) *Parameters {
+	return &Parameters{
+		values: cloneUrlValues(values),
+	}
+}
+
+// Require returns the Parameters found behind the `requireKey` key or an error if the key is missing.
+//   Parameters: root[sub][key]=value
+//   Go: params.Require("root")
+//   Returned parameters: sub[key]=value
+// The returned Parameters inherit the permitted state of the receiver.
+func (this *Parameters) Require(requireKey string) (*Parameters, error) {
+	required := Params().Require(requireKey)
+	if required.error != nil {
+		return nil, required.error
+	}
+
+	if err := required.validate(this.values); err != nil {
+		return nil, err
+	}
+
+	values, err := required.transform(cloneUrlValues(this.values))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Parameters{
+		values:    values,
+		permitted: this.permitted,
+	}, nil
+}
+
+// Permit returns new permitted Parameters containing only the keys whitelisted by the permit rules. The rules follow
+// the same syntax as StrongParams.Permit.
+func (this *Parameters) Permit(permitRule string, permitRules ...string) (*Parameters, error) {
+	permitted := Params().Permit(permitRule, permitRules...)
+	if permitted.error != nil {
+		return nil, permitted.error
+	}
+
+	values, err := permitted.transform(cloneUrlValues(this.values))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Parameters{
+		values:    values,
+		permitted: true,
+	}, nil
+}
+
+// IsPermitted returns whether the Parameters have been whitelisted with Parameters.Permit.
+func (this *Parameters) IsPermitted() bool {
+	return this.permitted
+}
+
+// Has returns whether the Parameters contain the `key` key or any nested key of it.
+func (this *Parameters) Has(key string) bool {
+	return hasKey(this.values, key)
+}
+
+// Fetch returns the value behind the `key` key or `defaultValue` if the key is missing. The value is either a string,
+// a []interface{} or a nested Tree as described by Tree.
+func (this *Parameters) Fetch(key string, defaultValue interface{}) interface{} {
+	if value := this.Dig(key); value != nil {
+		return value
+	}
+	return defaultValue
+}
+
+// Dig returns the nested value behind the `keys` path or nil if any of the keys is missing. Numeric keys can be used
+// to access array elements.
+//   Parameters: user[address][city]=Tallinn&user[tags][]=tag1
+//   Go: params.Dig("user", "address", "city") // "Tallinn"
+//   Go: params.Dig("user", "tags", "0")       // "tag1"
+func (this *Parameters) Dig(keys ...string) interface{} {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	tree, err := buildTree(this.Slice(keys[0]).values, nil)
+	if err != nil {
+		return nil
+	}
+
+	var current interface{} = tree
+	for _, key := range keys {
+		switch node := current.(type) {
+		case Tree:
+			current = node[key]
+		case []interface{}:
+			idx, err := strconv.Atoi(key)
+			if err != nil || idx < 0 || idx >= len(node) {
+				return nil
+			}
+			current = node[idx]
+		default:
+			return nil
+		}
+
+		if current == nil {
+			return nil
+		}
+	}
+	return current
+}
+
+// Slice returns new Parameters containing only the `keys` root keys and their nested keys.
+func (this *Parameters) Slice(keys ...string) *Parameters {
+	return this.filter(func(key string) bool {
+		return isKeyUnderAny(key, keys)
+	})
+}
+
+// Except returns new Parameters containing all but the `keys` root keys and their nested keys.
+func (this *Parameters) Except(keys ...string) *Parameters {
+	return this.filter(func(key string) bool {
+		return !isKeyUnderAny(key, keys)
+	})
+}
+
+// Merge returns new Parameters with the root keys of `other` replacing the same root keys of the receiver. The merged
+// Parameters are permitted only if both the receiver and `other` are permitted.
+func (this *Parameters) Merge(other *Parameters) *Parameters {
+	otherRoots := make([]string, 0, len(other.values))
+	for key := range other.values {
+		otherRoots = append(otherRoots, splitKey(key)[0])
+	}
+
+	merged := this.Except(otherRoots...)
+	for key, value := range cloneUrlValues(other.values) {
+		merged.values[key] = value
+	}
+	merged.permitted = this.permitted && other.permitted
+
+	return merged
+}
+
+// Each calls `fn` for every bracket notation key and its values in the ascending order of the keys.
+func (this *Parameters) Each(fn func(key string, values []string)) {
+	keys := make([]string, 0, len(this.values))
+	for key := range this.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := make([]string, len(this.values[key]))
+		copy(value, this.values[key])
+		fn(key, value)
+	}
+}
+
+// ToValues returns the Parameters as url.Values or an error if the Parameters are not permitted.
+func (this *Parameters) ToValues() (url.Values, error) {
+	if !this.permitted {
+		return nil, errUnpermittedParameters
+	}
+	return cloneUrlValues(this.values), nil
+}
+
+// ToMap returns the Parameters as a nested Tree or an error if the Parameters are not permitted.
+func (this *Parameters) ToMap() (Tree, error) {
+	if !this.permitted {
+		return nil, errUnpermittedParameters
+	}
+	return buildTree(this.values, nil)
+}
+
+var errUnpermittedParameters = errors.New("parameters are not permitted: call `Permit` before converting them")
+
+func (this *Parameters) filter(keep func(key string) bool) *Parameters {
+	values := make(url.Values)
+	for key, value := range this.values {
+		if keep(key) {
+			values[key] = make([]string, len(value))
+			copy(values[key], value)
+		}
+	}
+
+	return &Parameters{
+		values:    values,
+		permitted: this.permitted,
+	}
+}
+
+func isKeyUnderAny(key string, rootKeys []string) bool {
+	for _, rootKey := range rootKeys {
+		if isKeyUnder(key, rootKey) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package strongparams
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+)
+
+// PathValueExtractor extracts the path parameters of the route matched by a router, eg. "id" of "/users/{id}".
+type PathValueExtractor interface {
+	PathValues(request *http.Request) map[string]string
+}
+
+// PathValueExtractorFunc is an adapter to use an ordinary function as a PathValueExtractor.
+type PathValueExtractorFunc func(request *http.Request) map[string]string
+
+func (this PathValueExtractorFunc) PathValues(request *http.Request) map[string]string {
+	return this(request)
+}
+
+var rgxPatternWildcard = regexp.MustCompile(`\{(\w+)(?:\.\.\.)?\}`)
+
+// ServeMux is the PathValueExtractor of the net/http.ServeMux patterns. The wildcard names are resolved from the
+// http.Request's Pattern and the values from http.Request.PathValue.
+//   mux.HandleFunc("GET /orgs/{org}/users/{id}", handler) // {"org": "acme", "id": "1"}
+var ServeMux PathValueExtractor = PathValueExtractorFunc(func(request *http.Request) map[string]string {
+	pathValues := map[string]string{}
+	for _, match := range rgxPatternWildcard.FindAllStringSubmatch(request.Pattern, -1) {
+		pathValues[match[1]] = request.PathValue(match[1])
+	}
+	return pathValues
+})
+
+// GorillaMux returns the PathValueExtractor of the github.com/gorilla/mux routes. The `vars` parameter shall be the
+// mux.Vars function.
+//   PathValues(request, GorillaMux(mux.Vars))
+func GorillaMux(vars func(request *http.Request) map[string]string) PathValueExtractor {
+	return PathValueExtractorFunc(vars)
+}
+
+// Chi returns the PathValueExtractor of the github.com/go-chi/chi routes. The `urlParam` parameter shall be the
+// chi.URLParam function and `keys` the names of the URL parameters to extract.
+//   PathValues(request, Chi(chi.URLParam, "org", "id"))
+// The empty URL parameters are not extracted.
+func Chi(urlParam func(request *http.Request, key string) string, keys ...string) PathValueExtractor {
+	return PathValueExtractorFunc(func(request *http.Request) map[string]string {
+		pathValues := map[string]string{}
+		for _, key := range keys {
+			if value := urlParam(request, key); value != "" {
+				pathValues[key] = value
+			}
+		}
+		return pathValues
+	})
+}
+
+// PathValues returns the Source of the http.Request's path parameters named "path". The parameters are extracted by
+// the `extractors` and the first extractor of a parameter wins. The ServeMux extractor is used if none is passed.
+func PathValues(request *http.Request, extractors ...PathValueExtractor) Source {
+	return &namedSource{
+		name: "path",
+		loader: func(*strongParams, func(string) bool) *sourceValues {
+			return newPathSourceValues(request, extractors)
+		},
+	}
+}
+
+func newPathSourceValues(request *http.Request, extractors []PathValueExtractor) *sourceValues {
+	if len(extractors) == 0 {
+		extractors = []PathValueExtractor{ServeMux}
+	}
+
+	values := url.Values{}
+	for _, extractor := range extractors {
+		for key, value := range extractor.PathValues(request) {
+			if _, ok := values[key]; !ok {
+				values.Set(key, value)
+			}
+		}
+	}
+	return &sourceValues{
+		values: values,
+	}
+}
+
+// PathValues instructs the mechanism to process the http.Request's path parameters extracted by the `extractors`. Look
+// PathValues function for the details.
+//   mux.HandleFunc("GET /orgs/{org}/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+//       err := Params().Permit("org, id").PathValues(r)(&target)
+//   })
+// Use StrongParams.Sources to bind the path parameters together with the query or the body parameters.
+func (this *StrongParams) PathValues(request *http.Request, extractors ...PathValueExtractor) ReturnTarget {
+	return this.source(newPathSourceValues(request, extractors))
+}
@@ -0,0 +1,186 @@
+package strongparams
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// RawParam is a single key-value pair of a raw query string.
+type RawParam struct {
+	Key   string
+	Value string
+}
+
+// RawParams is the list of the key-value pairs of a raw query string in the order of their appearance.
+type RawParams []RawParam
+
+// Values returns the pairs as url.Values. The values of the repeated keys keep their order.
+func (this RawParams) Values() url.Values {
+	values := make(url.Values, len(this))
+	for _, param := range this {
+		values[param.Key] = append(values[param.Key], param.Value)
+	}
+	return values
+}
+
+// ParseErrorKind declares the kind of a ParseError.
+type ParseErrorKind int
+
+const (
+	// MalformedEscape declares the key or the value of the pair containing an invalid percent-encoding, eg. "%zz".
+	MalformedEscape ParseErrorKind = iota + 1
+	// Semicolon declares the pair containing a semicolon, eg. "a=1;b=2".
+	Semicolon
+	// EmptyKey declares the pair without a key, eg. "=value".
+	EmptyKey
+	// InvalidBrackets declares the key not following the brackets notation, eg. "a[b" or "a[b]c".
+	InvalidBrackets
+)
+
+func (this ParseErrorKind) String() string {
+	switch this {
+	case MalformedEscape:
+		return "malformed percent-encoding"
+	case Semicolon:
+		return "semicolon separator"
+	case EmptyKey:
+		return "empty key"
+	case InvalidBrackets:
+		return "invalid brackets"
+	}
+	return "unknown"
+}
+
+// ParseError is an error of a single pair of the raw query string reported by ParseRawStrict.
+type ParseError struct {
+	Kind ParseErrorKind
+	// Pair is the raw pair as it appears in the query string.
+	Pair string
+	// Offset is the byte offset of the pair in the query string.
+	Offset int
+}
+
+func (this *ParseError) Error() string {
+	return fmt.Sprintf("raw: %s in pair `%s` at offset %d", this.Kind, this.Pair, this.Offset)
+}
+
+// ParseErrors holds all the ParseError errors of the raw query string. The single errors are matched by errors.As.
+type ParseErrors []*ParseError
+
+func (this ParseErrors) Error() string {
+	return errorListMessage("", this)
+}
+
+func (this ParseErrors) Unwrap() []error {
+	return unwrapErrorList(this)
+}
+
+// The root key can be omitted as in "[]=value"
+var rgxRawKey = regexp.MustCompile("^[^\\[\\]]*(?:\\[[^\\[\\]]*\\])*$")
+
+// ParseRaw parses the `rawQuery` query string or application/x-www-form-urlencoded body into the ordered list of the
+// pairs. As done by url.ParseQuery, the pairs containing a semicolon or a malformed percent-encoding are dropped.
+func ParseRaw(rawQuery string) RawParams {
+	params, _ := parseRaw(rawQuery, false)
+	return params
+}
+
+// ParseRawStrict parses the `rawQuery` as ParseRaw but reports the malformed pairs, the semicolons, the empty keys and
+// the keys not following the brackets notation as ParseErrors. The valid pairs are returned along with the errors.
+//   params, err := ParseRawStrict("a=1&b[=2")
+//   // params: [{a 1}], err: raw: invalid brackets in pair `b[=2` at offset 4
+func ParseRawStrict(rawQuery string) (RawParams, error) {
+	params, errs := parseRaw(rawQuery, true)
+	if len(errs) > 0 {
+		return params, errs
+	}
+	return params, nil
+}
+
+// parseRaw parses the `rawQuery` into the pairs and the errors of the dropped pairs. The empty keys and the keys not
+// following the brackets notation are dropped only if `strict` is declared.
+func parseRaw(rawQuery string, strict bool) (RawParams, ParseErrors) {
+	params := RawParams{}
+	var errs ParseErrors
+
+	offset := 0
+	for _, pair := range strings.Split(rawQuery, "&") {
+		pairOffset := offset
+		offset += len(pair) + 1
+		if pair == "" {
+			continue
+		}
+
+		if strings.ContainsRune(pair, ';') {
+			errs = append(errs, &ParseError{Kind: Semicolon, Pair: pair, Offset: pairOffset})
+			continue
+		}
+
+		rawKey, rawValue, _ := strings.Cut(pair, "=")
+		key, keyErr := url.QueryUnescape(rawKey)
+		value, valueErr := url.QueryUnescape(rawValue)
+		if keyErr != nil || valueErr != nil {
+			errs = append(errs, &ParseError{Kind: MalformedEscape, Pair: pair, Offset: pairOffset})
+			continue
+		}
+
+		if strict && key == "" {
+			errs = append(errs, &ParseError{Kind: EmptyKey, Pair: pair, Offset: pairOffset})
+			continue
+		} else if strict && !rgxRawKey.MatchString(key) {
+			errs = append(errs, &ParseError{Kind: InvalidBrackets, Pair: pair, Offset: pairOffset})
+			continue
+		}
+		params = append(params, RawParam{Key: key, Value: value})
+	}
+
+	return params, errs
+}
+
+// WithStrictParsing instructs the Raw sources to reject the raw query strings reported by ParseRawStrict. The option
+// will be used on the returned *StrongParams struct pointer not on the receiver parameter.
+func (this *StrongParams) WithStrictParsing() *StrongParams {
+	params := this.clone()
+	params.strictParsing = true
+	return params
+}
+
+// Raw returns the Source of the `rawQuery` query string or application/x-www-form-urlencoded body named "raw". Look
+// StrongParams.Raw for the details.
+func Raw(rawQuery string) Source {
+	return &namedSource{
+		name: "raw",
+		loader: func(params *strongParams, _ func(string) bool) *sourceValues {
+			return newRawSourceValues(rawQuery, params.strictParsing)
+		},
+	}
+}
+
+func newRawSourceValues(rawQuery string, strict bool) *sourceValues {
+	params, errs := parseRaw(rawQuery, strict)
+	if strict && len(errs) > 0 {
+		return newSourceError(errs)
+	}
+	return &sourceValues{
+		values: groupedRawValues(params),
+	}
+}
+
+// newQuerySourceValues parses the query string of the `request` as done by url.URL.Query preserving the order of the
+// pairs for the Rack style arrays of objects.
+func newQuerySourceValues(request *http.Request) *sourceValues {
+	return newRawSourceValues(request.URL.RawQuery, false)
+}
+
+// Raw instructs the mechanism to parse and process the `rawQuery` query string or application/x-www-form-urlencoded
+// body, eg. http.Request's url.URL property's RawQuery. Unless StrongParams.WithStrictParsing is declared, the pairs
+// are parsed as done by url.ParseQuery.
+//   Params().WithStrictParsing().Permit("name").Raw(request.URL.RawQuery)(&user)
+// The strict parsing fails with ParseErrors on the malformed percent-encoding, the semicolons, the empty keys and the
+// keys not following the brackets notation.
+func (this *StrongParams) Raw(rawQuery string) ReturnTarget {
+	return this.source(newRawSourceValues(rawQuery, this.strictParsing))
+}
@@ -0,0 +1,132 @@
+package strongparams
+
+import (
+	"bytes"
+	"github.com/pkg/errors"
+	"io"
+	"mime"
+	"net/http"
+)
+
+// RequestLimits declares the size limits of the request bodies processed by StrongParams.Request and StrongParams.JSON.
+type RequestLimits struct {
+	// MaxBodyBytes limits the size of the application/x-www-form-urlencoded and the JSON bodies. The zero value falls
+	// back to the DefaultRequestLimits value and the negative value disables the limit.
+	MaxBodyBytes int64
+	// Multipart declares the limits of the multipart/form-data bodies. Look MultipartLimits for the details.
+	Multipart MultipartLimits
+}
+
+// DefaultRequestLimits holds the limits used for the zero value fields of RequestLimits.
+var DefaultRequestLimits = RequestLimits{
+	MaxBodyBytes: 10 << 20,
+	Multipart:    DefaultMultipartLimits,
+}
+
+func (this RequestLimits) withDefaults() RequestLimits {
+	if this.MaxBodyBytes == 0 {
+		this.MaxBodyBytes = DefaultRequestLimits.MaxBodyBytes
+	}
+	return this
+}
+
+const (
+	mediaTypeForm      = "application/x-www-form-urlencoded"
+	mediaTypeMultipart = "multipart/form-data"
+	mediaTypeJSON      = "application/json"
+)
+
+// requestSource selects the source of the parameters by the method and the Content-Type header of the `request`. The
+// query string is used for the GET, HEAD and DELETE requests. Otherwise the body parameters are merged with the query
+// parameters of the keys missing in the body.
+func (this *strongParams) requestSource(request *http.Request, keep func(key string) bool) *sourceValues {
+	switch request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete:
+		return newQuerySourceValues(request)
+	}
+
+	return this.mergeSources(FirstWins, []Source{Body(request), Query(request)}, keep)
+}
+
+// newBodySourceValues parses the `request` body by its media type. The request without a body has no parameters.
+func newBodySourceValues(request *http.Request, limits RequestLimits, keep func(key string) bool) *sourceValues {
+	if request.Body == nil || request.Body == http.NoBody {
+		return newSourceValues(nil)
+	}
+
+	contentType := request.Header.Get("Content-Type")
+	if contentType == "" {
+		return newSourceError(errors.New("request: missing `Content-Type` header of the request body"))
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return newSourceError(errors.Wrap(err, "request: invalid `Content-Type` header"))
+	}
+
+	limits = limits.withDefaults()
+	switch {
+	case mediaType == mediaTypeForm:
+		return newFormSourceValues(request, limits.MaxBodyBytes)
+	case mediaType == mediaTypeMultipart:
+		return newMultipartSourceValues(request, limits.Multipart, keep)
+	case isJSONMediaType(mediaType):
+		return newJSONSourceValues(request, limits)
+	}
+
+	return newSourceError(errors.Errorf("request: unsupported `Content-Type` of the request body: `%s`", mediaType))
+}
+
+func newFormSourceValues(request *http.Request, maxBodyBytes int64) *sourceValues {
+	body, err := readLimited(request.Body, maxBodyBytes)
+	if err != nil {
+		return newSourceError(err)
+	}
+
+	params, errs := parseRaw(string(body), false)
+	if len(errs) > 0 {
+		return newSourceError(errors.Wrap(errs[0], "request: failed to parse the form body"))
+	}
+	return &sourceValues{
+		values: groupedRawValues(params),
+	}
+}
+
+// readLimited reads the `reader` until EOF or returns a *LimitError if more than `max` bytes are read. The negative
+// `max` is not enforced.
+func readLimited(reader io.Reader, max int64) ([]byte, error) {
+	body := &bytes.Buffer{}
+	if _, err := copyLimited(body, reader, "", "MaxBodyBytes", max, -1, -1); err != nil {
+		return nil, err
+	}
+	return body.Bytes(), nil
+}
+
+// isJSONMediaType returns whether the `mediaType` is application/json or a structured syntax suffixed JSON media type,
+// eg. application/vnd.api+json.
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == mediaTypeJSON || (len(mediaType) > 5 && mediaType[len(mediaType)-5:] == "+json")
+}
+
+// WithRequestLimits declares the size limits of the request bodies processed by StrongParams.Request and
+// StrongParams.JSON. The limits will be used on the returned *StrongParams struct pointer not on the receiver
+// parameter.
+func (this *StrongParams) WithRequestLimits(limits RequestLimits) *StrongParams {
+	params := this.clone()
+	params.requestLimits = limits
+	return params
+}
+
+// Request instructs the mechanism to select the source of the parameters by the http.Request's method and Content-Type
+// header. It enables a single handler to serve both HTML forms and API clients:
+//   • GET, HEAD and DELETE requests and requests without a body: the query string
+//   • application/x-www-form-urlencoded: the form body
+//   • multipart/form-data: the streamed form body as done by StrongParams.Multipart
+//   • application/json and application/*+json: the JSON body as done by StrongParams.JSON
+// The query parameters of the keys missing in the body are added to the body parameters as done by
+// StrongParams.Sources with the FirstWins policy. The body sizes are limited by the limits declared with
+// StrongParams.WithRequestLimits or by DefaultRequestLimits. Other media types produce an error.
+func (this *StrongParams) Request(request *http.Request) ReturnTarget {
+	return this.source(this.requestSource(request, func(string) bool {
+		return true
+	}))
+}
@@ -0,0 +1,212 @@
+package strongparams
+
+import (
+	"github.com/pkg/errors"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Sanitizer normalizes the values of the processed parameters before decoding.
+type Sanitizer interface {
+	Sanitize(value string) string
+}
+
+// SanitizerFunc is a function implementing the Sanitizer interface.
+type SanitizerFunc func(value string) string
+
+func (this SanitizerFunc) Sanitize(value string) string {
+	return this(value)
+}
+
+var rgxWhitespaces = regexp.MustCompile("\\s+")
+var rgxHTMLTags = regexp.MustCompile("<[^>]*>")
+
+var sanitizersMutex sync.RWMutex
+var sanitizers = map[string]Sanitizer{
+	// trim removes the leading and the trailing whitespace
+	"trim": SanitizerFunc(strings.TrimSpace),
+	// squish removes the leading and the trailing whitespace and collapses the internal whitespace to single spaces
+	"squish": SanitizerFunc(func(value string) string {
+		return rgxWhitespaces.ReplaceAllString(strings.TrimSpace(value), " ")
+	}),
+	"lower": SanitizerFunc(strings.ToLower),
+	"upper": SanitizerFunc(strings.ToUpper),
+	// strip_control removes the control characters except the tabs and the line breaks
+	"strip_control": SanitizerFunc(func(value string) string {
+		return strings.Map(func(r rune) rune {
+			if unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r' {
+				return -1
+			}
+			return r
+		}, value)
+	}),
+	// strip_tags removes the substrings looking like the HTML tags, eg. "<b>". It doesn't parse HTML and it doesn't
+	// make the values safe to render: the values have to be escaped when rendered to prevent XSS.
+	"strip_tags": SanitizerFunc(func(value string) string {
+		return rgxHTMLTags.ReplaceAllString(value, "")
+	}),
+}
+
+// RegisterSanitizer registers the `sanitizer` by the `name` to be used in the StrongParams.WithSanitizers declarations.
+// The built-in sanitizers are "trim", "squish", "lower", "upper", "strip_control" and "strip_tags".
+func RegisterSanitizer(name string, sanitizer Sanitizer) {
+	sanitizersMutex.Lock()
+	defer sanitizersMutex.Unlock()
+	sanitizers[name] = sanitizer
+}
+
+// UnregisterSanitizer removes the sanitizer registered by the `name`, eg. to clean up the sanitizers registered by
+// tests. The declarations already using the sanitizer are not affected.
+func UnregisterSanitizer(name string) {
+	sanitizersMutex.Lock()
+	defer sanitizersMutex.Unlock()
+	delete(sanitizers, name)
+}
+
+func lookupSanitizer(name string) (Sanitizer, bool) {
+	sanitizersMutex.RLock()
+	defer sanitizersMutex.RUnlock()
+	sanitizer, ok := sanitizers[name]
+	return sanitizer, ok
+}
+
+type pathSanitizers struct {
+	segments   []string
+	sanitizers []Sanitizer
+}
+
+// WithSanitizers declares the sanitizers of the values by the `declarations` of the path and the comma separated names
+// of the registered sanitizers applied in the declared order:
+//   Go: Params().WithSanitizers("user[email]: trim,lower", "user[tags][]: squish").
+//       Require("user").Permit("email, tags:[]")
+//   Query: user[email]=%20John@Example.COM&user[tags][]=a%20%20b
+//   Decoded as: email=john@example.com&tags[]=a%20b
+// The paths are the keys before applying Require and apply also to the nested keys. The "[]" segments match any array
+// element. The sanitizers are applied after applying Permit and before decoding. The invalid declarations produce an
+// error but it is not returned until executing ReturnTarget function. The sanitizers will be used on the returned
+// *StrongParams struct pointer not on the receiver parameter.
+func (this *StrongParams) WithSanitizers(declarations ...string) *StrongParams {
+	params := this.clone()
+	for _, declaration := range declarations {
+		path, names, ok := strings.Cut(declaration, ":")
+		path = strings.TrimSpace(path)
+		if !ok || path == "" || !rgxBracketKey.MatchString(path) {
+			params.configError = errors.Errorf("sanitizers: invalid declaration `%s`", declaration)
+			return params
+		}
+
+		var pathSanitizers []Sanitizer
+		for _, name := range strings.Split(names, ",") {
+			sanitizer, ok := lookupSanitizer(strings.TrimSpace(name))
+			if !ok {
+				params.configError = errors.Errorf("sanitizers: unknown sanitizer `%s` of path `%s`",
+					strings.TrimSpace(name), path)
+				return params
+			}
+			pathSanitizers = append(pathSanitizers, sanitizer)
+		}
+		params = params.withSanitizer(path, pathSanitizers)
+	}
+	return params
+}
+
+// WithSanitizer declares the `sanitizers` of the values of the `path` key and its nested keys. Look
+// StrongParams.WithSanitizers for the details.
+func (this *StrongParams) WithSanitizer(path string, sanitizers ...Sanitizer) *StrongParams {
+	return this.clone().withSanitizer(path, sanitizers)
+}
+
+func (this *StrongParams) withSanitizer(path string, sanitizers []Sanitizer) *StrongParams {
+	declared := make([]pathSanitizers, len(this.sanitizers), len(this.sanitizers)+1)
+	copy(declared, this.sanitizers)
+	this.sanitizers = append(declared, pathSanitizers{segments: splitKey(path), sanitizers: sanitizers})
+	return this
+}
+
+// matches returns whether the `key` is the path or nested in the path. The empty path segments match any array element.
+func (this pathSanitizers) matches(key string) bool {
+	segments := splitKey(key)
+	if len(segments) < len(this.segments) {
+		return false
+	}
+	for idx, segment := range this.segments {
+		isElement := segments[idx] == "" || rgxCollectionIndex.MatchString(segments[idx])
+		if segment != segments[idx] && !(segment == "" && idx > 0 && isElement) {
+			return false
+		}
+	}
+	return true
+}
+
+func (this pathSanitizers) sanitize(value string) string {
+	for _, sanitizer := range this.sanitizers {
+		value = sanitizer.Sanitize(value)
+	}
+	return value
+}
+
+// sanitized returns the sourceValues with the values sanitized by the declared sanitizers. The paths are transformed
+// by the transformPath of the receiver.
+func (this *strongParams) sanitized(source *sourceValues) *sourceValues {
+	if len(this.sanitizers) == 0 {
+		return source
+	}
+
+	var declared []pathSanitizers
+	for _, declaration := range this.sanitizers {
+		if source.transformPath != nil {
+			path, ok := source.transformPath(joinSegments(declaration.segments))
+			if !ok {
+				continue
+			}
+			declaration.segments = nil
+			if path != "" {
+				declaration.segments = splitKey(path)
+			}
+		}
+		declared = append(declared, declaration)
+	}
+
+	sanitized := *source
+	sanitized.values = make(url.Values, len(source.values))
+	sanitized.typed = nil
+	for key, value := range source.values {
+		sanitized.values[key] = value
+	}
+	for key, value := range source.typed {
+		if sanitized.typed == nil {
+			sanitized.typed = make(map[string][]interface{}, len(source.typed))
+		}
+		sanitized.typed[key] = value
+	}
+
+	for _, declaration := range declared {
+		for key, value := range sanitized.values {
+			if !declaration.matches(key) {
+				continue
+			}
+
+			values := make([]string, len(value))
+			for idx, stringValue := range value {
+				values[idx] = declaration.sanitize(stringValue)
+			}
+			sanitized.values[key] = values
+
+			if typed, ok := sanitized.typed[key]; ok {
+				typedValues := make([]interface{}, len(typed))
+				for idx, typedValue := range typed {
+					if stringValue, isString := typedValue.(string); isString {
+						typedValue = declaration.sanitize(stringValue)
+					}
+					typedValues[idx] = typedValue
+				}
+				sanitized.typed[key] = typedValues
+			}
+		}
+	}
+
+	return &sanitized
+}
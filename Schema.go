@@ -0,0 +1,97 @@
+package strongparams
+
+import (
+	"github.com/vellotis/go-strongparams/permitter"
+	"net/http"
+	"net/url"
+	"reflect"
+)
+
+// Schema is a typed binder declared once per endpoint. It holds the pre-parsed Require and Permit rules and binds the
+// request parameters directly to a value of type T.
+//   type UserInput struct {
+//       Name  string   `params:"name"`
+//       Email string   `params:"email"`
+//       Tags  []string `params:"tags"`
+//   }
+//   var CreateUser = NewSchema[UserInput]("user", "name, email, tags:[]")
+//
+//   func handler(w http.ResponseWriter, r *http.Request) {
+//       in, err := CreateUser.Bind(r)
+//       // handle error
+//   }
+// Schema is safe for concurrent use.
+type Schema[T any] struct {
+	params          *StrongParams
+	requireKey      *string
+	rules           permitter.Permittable
+	crossFieldRules []crossFieldRule
+	defaults        url.Values
+}
+
+// NewSchema creates a Schema binding to T. The `requireKey` parameter declares the key required by StrongParams.Require
+// and can be empty to bind the parameters root. The permit rules follow the syntax of StrongParams.Permit.
+//
+// **NOTE** The function panics if the rules fail to parse or T is a struct inconsistent with the rules as reported by
+// Check.
+func NewSchema[T any](requireKey string, permitRule string, permitRules ...string) *Schema[T] {
+	return NewSchemaWithParams[T](Params(), requireKey, permitRule, permitRules...)
+}
+
+// NewSchemaWithParams is equivalent to NewSchema but uses the `params` configured StrongParams, eg. with an explicit
+// decoder, instead of Params().
+func NewSchemaWithParams[T any](params *StrongParams, requireKey string, permitRule string,
+	permitRules ...string) *Schema[T] {
+
+	parsed := &strongParamsRequiredAndPermitted{}
+	if err := parsed.parsePermitRules(append(permitRules, permitRule)); err != nil {
+		panic(err)
+	}
+
+	var target T
+	if targetType := reflect.TypeOf(&target).Elem(); targetType.Kind() == reflect.Struct {
+		if err := params.check(parsed.permitRules, &target); err != nil {
+			panic(err)
+		}
+	}
+
+	schema := &Schema[T]{
+		params:          params,
+		rules:           parsed.permitRules,
+		crossFieldRules: parsed.crossFieldRules,
+		defaults:        parsed.defaults,
+	}
+	if requireKey != "" {
+		schema.requireKey = &requireKey
+	}
+	return schema
+}
+
+// Bind binds the http.Request's parameters to a new value of T. The source of the parameters is selected by the
+// request's method and Content-Type header as done by StrongParams.Request.
+func (this *Schema[T]) Bind(request *http.Request) (T, error) {
+	var target T
+	err := this.permitted().Request(request)(&target)
+	return target, err
+}
+
+// BindValues binds the url.Values from `values` parameter to a new value of T.
+func (this *Schema[T]) BindValues(values url.Values) (T, error) {
+	var target T
+	err := this.permitted().Values(values)(&target)
+	return target, err
+}
+
+func (this *Schema[T]) permitted() *StrongParamsRequiredAndPermitted {
+	return &StrongParamsRequiredAndPermitted{
+		&strongParamsRequiredAndPermitted{
+			strongParamsRequired: &strongParamsRequired{
+				strongParams: this.params.strongParams,
+				requireKey:   this.requireKey,
+			},
+			permitRules:     this.rules,
+			crossFieldRules: this.crossFieldRules,
+			defaults:        this.defaults,
+		},
+	}
+}
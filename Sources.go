@@ -0,0 +1,179 @@
+package strongparams
+
+import (
+	"github.com/pkg/errors"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+)
+
+// Source is a source of the request parameters merged by StrongParams.Sources. The sources are created with Query,
+// Body and Values functions.
+type Source interface {
+	// Name returns the name of the source used in the error messages, eg. "query".
+	Name() string
+	load(params *strongParams, keep func(key string) bool) *sourceValues
+}
+
+type namedSource struct {
+	name   string
+	loader func(params *strongParams, keep func(key string) bool) *sourceValues
+}
+
+func (this *namedSource) Name() string {
+	return this.name
+}
+
+func (this *namedSource) load(params *strongParams, keep func(key string) bool) *sourceValues {
+	return this.loader(params, keep)
+}
+
+// Query returns the Source of the http.Request's query string parameters named "query".
+func Query(request *http.Request) Source {
+	return &namedSource{
+		name: "query",
+		loader: func(*strongParams, func(string) bool) *sourceValues {
+			return newQuerySourceValues(request)
+		},
+	}
+}
+
+// Body returns the Source of the http.Request's body parameters named "body". The body is parsed by its Content-Type
+// header as done by StrongParams.Request and the request without a body has no parameters.
+func Body(request *http.Request) Source {
+	return &namedSource{
+		name: "body",
+		loader: func(params *strongParams, keep func(string) bool) *sourceValues {
+			return newBodySourceValues(request, params.requestLimits, keep)
+		},
+	}
+}
+
+// Values returns the Source of the url.Values from `values` parameter named by `name` parameter.
+func Values(name string, values url.Values) Source {
+	return &namedSource{
+		name: name,
+		loader: func(*strongParams, func(string) bool) *sourceValues {
+			return newSourceValues(values)
+		},
+	}
+}
+
+// MergePolicy declares how StrongParams.Sources resolves a key present in multiple sources.
+type MergePolicy int
+
+const (
+	// FirstWins keeps the values of the first source containing the key. It is the default policy.
+	FirstWins MergePolicy = iota
+	// LastWins keeps the values of the last source containing the key.
+	LastWins
+	// ErrorOnConflict produces an error if the sources contain different values of the key.
+	ErrorOnConflict
+)
+
+// WithMergePolicy declares how StrongParams.Sources resolves a key present in multiple sources. The policy will be used
+// on the returned *StrongParams struct pointer not on the receiver parameter. The default is FirstWins.
+func (this *StrongParams) WithMergePolicy(policy MergePolicy) *StrongParams {
+	params := this.clone()
+	params.mergePolicy = policy
+	return params
+}
+
+// Sources instructs the mechanism to merge the parameters of the `sources` in the declared order before applying
+// Require and Permit. The keys present in multiple sources are resolved by the policy declared with
+// StrongParams.WithMergePolicy.
+//   Params().Require("user").Permit("name").Sources(Query(request), Body(request))(&user)
+// The decoding errors name the source the failed key came from.
+func (this *StrongParams) Sources(sources ...Source) ReturnTarget {
+	return this.source(this.mergeSources(this.mergePolicy, sources, func(string) bool {
+		return true
+	}))
+}
+
+// mergeSources loads and merges the `sources` by the `policy` merge policy. The conflicts of the keys not kept by
+// `keep` are not reported as the keys are dropped later. The dedicated struct tag of the sources is kept only if all
+// the sources share it.
+func (this *strongParams) mergeSources(policy MergePolicy, sources []Source, keep func(key string) bool) *sourceValues {
+	merged := &sourceValues{
+		values:  url.Values{},
+		origins: map[string]string{},
+	}
+
+	for idx, source := range sources {
+		loaded := source.load(this, keep)
+		if loaded.error != nil {
+			merged.removeFiles()
+			return loaded
+		}
+		for fileHeader, form := range loaded.fileForms {
+			if merged.fileForms == nil {
+				merged.fileForms = map[*multipart.FileHeader]*multipart.Form{}
+			}
+			merged.fileForms[fileHeader] = form
+		}
+
+		if idx == 0 {
+			merged.aliasTag = loaded.aliasTag
+		} else if merged.aliasTag != loaded.aliasTag {
+			merged.aliasTag = ""
+		}
+
+		for key, value := range loaded.values {
+			if origin, ok := merged.origins[key]; ok {
+				if policy == ErrorOnConflict && keep(key) && isConflicting(merged, loaded, key) {
+					merged.removeFiles()
+					return newSourceError(errors.Errorf("sources: conflicting values of key `%s` from `%s` and `%s`",
+						key, origin, source.Name()))
+				} else if policy != LastWins {
+					continue
+				}
+			}
+
+			merged.values[key] = value
+			merged.origins[key] = source.Name()
+			merged.setTyped(key, loaded.typed)
+			merged.setFiles(key, loaded.files)
+		}
+	}
+
+	return merged
+}
+
+func isConflicting(merged *sourceValues, loaded *sourceValues, key string) bool {
+	if len(merged.files[key]) != 0 || len(loaded.files[key]) != 0 {
+		return true
+	}
+
+	mergedValues, loadedValues := merged.values[key], loaded.values[key]
+	if len(mergedValues) != len(loadedValues) {
+		return true
+	}
+	for idx := range mergedValues {
+		if mergedValues[idx] != loadedValues[idx] {
+			return true
+		}
+	}
+	return false
+}
+
+func (this *sourceValues) setTyped(key string, typed map[string][]interface{}) {
+	if value, ok := typed[key]; ok {
+		if this.typed == nil {
+			this.typed = map[string][]interface{}{}
+		}
+		this.typed[key] = value
+	} else {
+		delete(this.typed, key)
+	}
+}
+
+func (this *sourceValues) setFiles(key string, files map[string][]*multipart.FileHeader) {
+	if value, ok := files[key]; ok {
+		if this.files == nil {
+			this.files = map[string][]*multipart.FileHeader{}
+		}
+		this.files[key] = value
+	} else {
+		delete(this.files, key)
+	}
+}
@@ -32,8 +32,15 @@ func assertStringParser(candidate StringParser) {
 	}
 }
 
-func callStringParser(parser StringParser, requireKey string, queryValues url.Values) (interface{}, error) {
-	keyValue := queryValues.Get(requireKey)
+func callStringParser(parser StringParser, requireKey string, queryValues url.Values,
+	policy DuplicatePolicy) (interface{}, error) {
+
+	values, ok := policy.resolve(queryValues[requireKey])
+	if !ok {
+		return nil, &DuplicateKeysError{Keys: []string{requireKey}}
+	}
+
+	keyValue := values[0]
 	result := reflect.ValueOf(parser).Call([]reflect.Value{
 		reflect.ValueOf(keyValue),
 	})
@@ -19,8 +19,24 @@ type StrongParams struct {
 }
 
 type strongParams struct {
-	decoder     *schema.Decoder
-	valueGetter func() url.Values
+	decoder       *schema.Decoder
+	aliasTag      string
+	requestLimits RequestLimits
+	mergePolicy   MergePolicy
+	valueGetter   func() url.Values
+
+	normalizeCollections bool
+	arrayFormat          ArrayFormat
+	arrayFormats         map[string]ArrayFormat
+	notation             Notation
+	strictParsing        bool
+	duplicatePolicy      DuplicatePolicy
+	limits               Limits
+	blankHandling        BlankHandling
+	keyCase              KeyCase
+	sanitizers           []pathSanitizers
+	validator            Validator
+	configError          error
 }
 
 // ReturnTarget enables just features of schema.Decoder (https://github.com/gorilla/schema) without performing
@@ -28,7 +44,8 @@ type strongParams struct {
 // string "root[0][key]". Given method enables using the standard query string brackets format with schema.Decoder.
 // Before passing the url.Values to schema.Decoder the keys are transposed to the required dot notation.
 //
-// The `target` parameter shall be a pointer to the parsable struct.
+// The `target` parameter shall be a pointer to the parsable struct. Alternatively a *Tree or a *map[string]interface{}
+// can be passed to receive the parameters as nested maps and slices instead of decoding them with schema.Decoder.
 type ReturnTarget func(target interface {}) error
 
 // Params declares the *http.Request to be used for the strong parameters mechanism.
@@ -38,7 +55,8 @@ func Params() *StrongParams {
 
 	return &StrongParams{
 		&strongParams{
-			decoder: defaultDecoder,
+			decoder:  defaultDecoder,
+			aliasTag: defaultAliasTag,
 		},
 	}
 }
@@ -47,16 +65,29 @@ func Params() *StrongParams {
 // used on the returned *StrongParams struct pointer not on the receiver parameter. To use new implicitly defined
 // schema.Decoder, look WithDecoder method instead.
 func (this *StrongParams) WithDecoder(decoder *schema.Decoder) *StrongParams {
-	return &StrongParams{
-		&strongParams{
-			decoder: decoder,
-		},
-	}
+	params := this.clone()
+	params.decoder = decoder
+	return params
+}
+
+// StrongParams.WithAliasTag declares the struct tag the decoder resolves the keys from. The tag is used by the
+// mechanisms which inspect the target struct themselves, eg. StrongParams.PermitStruct. It has to be declared when an
+// explicit decoder with a custom alias tag is used. The default is `params`.
+func (this *StrongParams) WithAliasTag(aliasTag string) *StrongParams {
+	params := this.clone()
+	params.aliasTag = aliasTag
+	return params
 }
 
-// Query instructs the mechanism to process http.Request's url.URL property url.URL/Query() method returned url.Values.
+func (this *StrongParams) clone() *StrongParams {
+	copied := *this.strongParams
+	return &StrongParams{&copied}
+}
+
+// Query instructs the mechanism to process http.Request's url.URL property's query string parsed as done by
+// url.URL/Query() method. The order of the pairs is preserved for the Rack style arrays of objects.
 func (this *StrongParams) Query(request *http.Request) ReturnTarget {
-	return this.Values(request.URL.Query())
+	return this.source(newQuerySourceValues(request))
 }
 
 // PostForm instructs the mechanism to process http.Request's url.PostForm property's url.Values.
@@ -64,16 +95,34 @@ func (this *StrongParams) PostForm(request *http.Request) ReturnTarget {
 	return this.Values(request.PostForm)
 }
 
+// JSON instructs the mechanism to process the http.Request's JSON body. The JSON document must be an object and it is
+// flattened into the brackets notation keys before applying Require and Permit with the same semantics as forms:
+//   JSON: {"root":{"key":"value","arr":[1,true],"objs":[{"key":null}]}}
+//   Processed as: root[key]=value&root[arr][]=1&root[arr][]=true&root[objs][0][key]
+// The JSON number, boolean and null types are preserved: null values leave the target struct fields untouched and
+// Tree targets receive json.Number, bool and nil values instead of strings.
+func (this *StrongParams) JSON(request *http.Request) ReturnTarget {
+	return this.source(newJSONSourceValues(request, this.requestLimits))
+}
+
 // Values instructs the mechanism to process url.Values from `values` parameter.
 func (this *StrongParams) Values(values url.Values) ReturnTarget {
-	values = cloneUrlValues(values)
+	return this.source(newSourceValues(values))
+}
+
+func (this *StrongParams) source(source *sourceValues) ReturnTarget {
+	source = this.normalizedArrays(this.prepared(source), nil)
 
 	return func(target interface{}) error {
-		if target == nil {
+		defer source.removeFiles()
+
+		if source.error != nil {
+			return source.error
+		} else if target == nil {
 			return errors.New("`target` argument cannot be nil")
 		}
 
-		return this.decode(values, target)
+		return this.decode(source, target)
 	}
 }
 
@@ -111,21 +160,49 @@ func (this *StrongParams) RequireOne(requireKey string) *StrongParamsRequireOne
 }
 
 // Permit instructs to apply the rules to whitelist the keys in url.Values before decoding it to the target struct.
+// The cross-field rules, eg. "oneOf(email, phone)", and the default values, eg. "per_page=25", are declared at the top
+// level of the rules. Look StrongParamsRequiredAndPermitted.ExactlyOneOf and StrongParamsRequiredAndPermitted.Default
+// for the details.
 func (this *StrongParams) Permit(permitRule string, permitRules... string) *StrongParamsRequiredAndPermitted {
-	rules, err := permitter.ParsePermitted(
-		funk.Uniq(append(permitRules, permitRule)).([]string)...
-	)
+	params := &strongParamsRequiredAndPermitted{
+		strongParamsRequired: &strongParamsRequired{
+			strongParams: this.strongParams,
+		},
+	}
+	params.error = params.parsePermitRules(append(permitRules, permitRule))
+	return &StrongParamsRequiredAndPermitted{params}
+}
+
+// PermitStruct instructs to whitelist the keys derived from the `target` struct fields before decoding url.Values to
+// the target struct. The keys are resolved from the decoder alias tag. Fields tagged with `permit:"-"` are never
+// permitted and fields tagged with `permit:"role1,role2"` are permitted only if any of the roles is passed in `roles`.
+//   type User struct {
+//       Name  string `params:"name"`
+//       Admin bool   `params:"admin" permit:"admin"`
+//   }
+//   Params().PermitStruct(&User{})          // equivalent to Params().Permit("name")
+//   Params().PermitStruct(&User{}, "admin") // equivalent to Params().Permit("name, admin")
+// Look permitter.FromType for the details.
+func (this *StrongParams) PermitStruct(target interface{}, roles ...string) *StrongParamsRequiredAndPermitted {
+	rules, err := this.permitStructRules(target, roles)
 	return &StrongParamsRequiredAndPermitted{
 		&strongParamsRequiredAndPermitted{
 			strongParamsRequired: &strongParamsRequired{
 				strongParams: this.strongParams,
-				error: err,
+				error:        err,
 			},
 			permitRules: rules,
 		},
 	}
 }
 
+func (this *strongParams) permitStructRules(target interface{}, roles []string) (permitter.Permittable, error) {
+	if target == nil {
+		return nil, errors.New("`target` argument cannot be nil")
+	}
+	return permitter.FromTypeWithAliasTag(reflect.TypeOf(target), this.aliasTag, roles...)
+}
+
 var rgxMatchStartEndBrackets = regexp.MustCompile("(?:^\\[)|(?:\\]$)")
 var rgxMatchMiddleBrackets = regexp.MustCompile("(?:\\]\\[)|(?:\\[)")
 func transposeToDotNotation(dotNotationQueryKey string) string {
@@ -134,26 +211,107 @@ func transposeToDotNotation(dotNotationQueryKey string) string {
 	return transposedKey
 }
 
-func (this *strongParams) decode(queryValues url.Values, target interface{}) error {
-	err := schema.MultiError{}
+func (this *strongParams) decode(source *sourceValues, target interface{}) error {
+	source, err := source.grouped()
+	if err != nil {
+		return err
+	}
+	if this.normalizeCollections {
+		source = source.normalizedCollections()
+	}
+	source = this.sanitized(source)
+
+	switch typedTarget := target.(type) {
+	case *Tree:
+		treeSource := source.blanksHandled(this.blankHandling)
+		tree, err := buildTree(treeSource.values, treeSource.typed)
+		if err != nil {
+			return err
+		}
+		*typedTarget = tree
+		return nil
+
+	case *map[string]interface{}:
+		treeSource := source.blanksHandled(this.blankHandling)
+		tree, err := buildTree(treeSource.values, treeSource.typed)
+		if err != nil {
+			return err
+		}
+		*typedTarget = tree
+		return nil
+	}
+
+	// The pointer fields of the empty values are resolved after transposing the keys
+	source = source.blanksHandled(this.blankHandling &^ EmptyAsNil)
+
+	multiErr := schema.MultiError{}
 	transposedQueryValues := url.Values{}
+	transposedOrigins := map[string]string{}
 
-	for key, value := range queryValues {
-		if strings.ContainsRune(key, '.') {
-			err[key] = errors.Errorf("`%s` contains `.` character", key)
+	for key, value := range source.values {
+		// The values of "key[]" and "key" are decoded into the same slice
+		segments := unescapeKeySegments(strings.Split(transposeToDotNotation(strings.TrimSuffix(key, "[]")), "."))
+		if strings.ContainsRune(key, '.') || strings.ContainsRune(strings.Join(segments, ""), '.') {
+			multiErr[key] = errors.Errorf("`%s` contains `.` character", key)
+			continue
+		} else if len(value) == 0 {
 			continue
 		}
 
-		transposedKey := transposeToDotNotation(key)
-		transposedQueryValues[transposedKey] = value
+		transposedKey := strings.Join(segments, ".")
+		transposedQueryValues[transposedKey] = append(transposedQueryValues[transposedKey], value...)
+		if origin, ok := source.origins[key]; ok {
+			transposedOrigins[transposedKey] = origin
+		}
 	}
 
-	if funk.NotEmpty(err) {
-		return errors.WithMessage(err, "any of the query keys should not contain `.` character." +
+	if funk.NotEmpty(multiErr) {
+		return errors.WithMessage(multiErr, "any of the query keys should not contain `.` character." +
 			"The brackets query notation is transposed to a dot notation which is required by the " +
 			"`github.com/gorilla/struct` decoder.")
 	}
 
-	return this.decoder.Decode(target, transposedQueryValues)
+	// The keys of the dedicated tag are decoded by the keys of the declared alias tag
+	aliasTag, keyTag := this.aliasTag, this.aliasTag
+	if source.aliasTag != "" {
+		var retagErr error
+		aliasTag, keyTag = this.aliasTag, source.aliasTag
+		transposedQueryValues, transposedOrigins, retagErr = retagged(transposedQueryValues, transposedOrigins, target,
+			source.aliasTag, aliasTag)
+		if retagErr != nil {
+			return retagErr
+		}
+	}
+
+	if this.blankHandling&EmptyAsNil != 0 {
+		emptyAsNil(transposedQueryValues, target, aliasTag)
+	}
+	if err := this.duplicatePolicy.resolveDuplicates(transposedQueryValues, target, aliasTag); err != nil {
+		return err
+	}
+
+	if err := this.decoder.Decode(target, transposedQueryValues); err != nil {
+		return withOrigins(err, transposedOrigins)
+	}
+
+	if err := this.bindFiles(source, target); err != nil {
+		return err
+	}
+
+	return mergeValidationErrors(this.validated(target, keyTag, source.untransformKey))
 }
 
+// withOrigins prefixes the schema.MultiError errors of the keys with the names of the sources the keys came from.
+func withOrigins(err error, origins map[string]string) error {
+	multiErr, ok := err.(schema.MultiError)
+	if !ok || len(origins) == 0 {
+		return err
+	}
+
+	for key, keyErr := range multiErr {
+		if origin, ok := origins[key]; ok {
+			multiErr[key] = errors.WithMessagef(keyErr, "source `%s`", origin)
+		}
+	}
+	return multiErr
+}
@@ -17,9 +17,10 @@ type StrongParamsRequireOne struct {
 // type.
 type ReturnOfType func(StringParser) (interface {}, error)
 
-// Query instructs the mechanism to process http.Request's url.URL property url.URL/Query() method returned url.Values.
+// Query instructs the mechanism to process http.Request's url.URL property's query string parsed as done by
+// url.URL/Query() method. The order of the pairs is preserved for the Rack style arrays of objects.
 func (this *StrongParamsRequireOne) Query(request *http.Request) ReturnOfType {
-	return this.Values(request.URL.Query())
+	return this.source(newQuerySourceValues(request))
 }
 
 // PostForm instructs the mechanism to process http.Request's url.PostForm property's url.Values.
@@ -27,25 +28,82 @@ func (this *StrongParamsRequireOne) PostForm(request *http.Request) ReturnOfType
 	return this.Values(request.PostForm)
 }
 
+// JSON instructs the mechanism to process the http.Request's JSON body. Look StrongParams.JSON for the details.
+func (this *StrongParamsRequireOne) JSON(request *http.Request) ReturnOfType {
+	return this.source(newJSONSourceValues(request, this.requestLimits))
+}
+
+// Multipart instructs the mechanism to stream the http.Request's multipart/form-data body. Look StrongParams.Multipart
+// for the details.
+func (this *StrongParamsRequireOne) Multipart(request *http.Request, limits MultipartLimits) ReturnOfType {
+	return this.source(newMultipartSourceValues(request, limits, func(key string) bool {
+		return this.sourceKey(key) == *this.requireKey
+	}))
+}
+
+// Request instructs the mechanism to select the source of the parameters by the http.Request's method and
+// Content-Type header. Look StrongParams.Request for the details.
+func (this *StrongParamsRequireOne) Request(request *http.Request) ReturnOfType {
+	return this.source(this.requestSource(request, func(key string) bool {
+		return this.sourceKey(key) == *this.requireKey
+	}))
+}
+
+// PathValues instructs the mechanism to process the http.Request's path parameters extracted by the `extractors`.
+// Look PathValues function for the details.
+func (this *StrongParamsRequireOne) PathValues(request *http.Request, extractors ...PathValueExtractor) ReturnOfType {
+	return this.source(newPathSourceValues(request, extractors))
+}
+
+// Headers instructs the mechanism to process the http.Request's headers. Look StrongParams.Headers for the details.
+func (this *StrongParamsRequireOne) Headers(request *http.Request) ReturnOfType {
+	return this.source(newHeaderSourceValues(request))
+}
+
+// Cookies instructs the mechanism to process the http.Request's cookies. Look StrongParams.Cookies for the details.
+func (this *StrongParamsRequireOne) Cookies(request *http.Request) ReturnOfType {
+	return this.source(newCookieSourceValues(request))
+}
+
+// Sources instructs the mechanism to merge the parameters of the `sources` in the declared order. Look
+// StrongParams.Sources for the details.
+func (this *StrongParamsRequireOne) Sources(sources ...Source) ReturnOfType {
+	return this.source(this.mergeSources(this.mergePolicy, sources, func(key string) bool {
+		return this.sourceKey(key) == *this.requireKey
+	}))
+}
+
+// Raw instructs the mechanism to parse and process the `rawQuery` query string or application/x-www-form-urlencoded
+// body. Look StrongParams.Raw for the details.
+func (this *StrongParamsRequireOne) Raw(rawQuery string) ReturnOfType {
+	return this.source(newRawSourceValues(rawQuery, this.strictParsing))
+}
+
 // Values instructs the mechanism to process url.Values from `values` parameter.
 func (this *StrongParamsRequireOne) Values(values url.Values) ReturnOfType {
-	values = cloneUrlValues(values)
+	return this.source(newSourceValues(values))
+}
+
+func (this *StrongParamsRequireOne) source(source *sourceValues) ReturnOfType {
+	source = this.prepared(source)
 
 	return func(parser StringParser) (interface{}, error) {
 		assertStringParser(parser)
+		defer source.removeFiles()
 
-		if err := this.validate(values); err != nil {
+		if source.error != nil {
+			return nil, source.error
+		} else if err := this.validate(source.values); err != nil {
 			return nil, err
 		}
 
-		return callStringParser(parser, *this.requireKey, values)
+		return callStringParser(parser, *this.requireKey, this.sanitized(source).values, this.duplicatePolicy)
 	}
 }
 
 func (this *StrongParamsRequireOne) validate(values url.Values) error {
 	if this.requireKey != nil {
-		_, hasKey := values[*this.requireKey]
-		if !hasKey {
+		if len(values[*this.requireKey]) == 0 {
 			return errors.Errorf("query: missing required key: `%s`", *this.requireKey)
 		}
 	}
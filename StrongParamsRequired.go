@@ -2,8 +2,6 @@ package strongparams
 
 import (
 	"github.com/pkg/errors"
-	"github.com/thoas/go-funk"
-	"github.com/vellotis/go-strongparams/permitter"
 	"net/http"
 	"net/url"
 	"strings"
@@ -35,17 +33,33 @@ func (this *StrongParamsRequired) Permit(permitRule  string, permitRules... stri
 	}
 
 	if params.error == nil {
-		params.permitRules, params.error = permitter.ParsePermitted(
-			funk.Uniq(append(permitRules, permitRule)).([]string)...
-		)
+		params.error = params.parsePermitRules(append(permitRules, permitRule))
 	}
 
 	return &params
 }
 
-// Query instructs the mechanism to process http.Request's url.URL property url.URL/Query() method returned url.Values.
+// PermitStruct instructs to whitelist the keys derived from the `target` struct fields. The rules are applied on the
+// object found behind the parameter `requireKey` defined key instructed by StrongParams.Require method. Look
+// StrongParams.PermitStruct for the details.
+func (this *StrongParamsRequired) PermitStruct(target interface{}, roles ...string) *StrongParamsRequiredAndPermitted {
+	params := StrongParamsRequiredAndPermitted{
+		&strongParamsRequiredAndPermitted{
+			strongParamsRequired: this.strongParamsRequired,
+		},
+	}
+
+	if params.error == nil {
+		params.permitRules, params.error = this.permitStructRules(target, roles)
+	}
+
+	return &params
+}
+
+// Query instructs the mechanism to process http.Request's url.URL property's query string parsed as done by
+// url.URL/Query() method. The order of the pairs is preserved for the Rack style arrays of objects.
 func (this *StrongParamsRequired) Query(request *http.Request) ReturnTarget {
-	return this.Values(request.URL.Query())
+	return this.source(newQuerySourceValues(request))
 }
 
 // PostForm instructs the mechanism to process http.Request's url.PostForm property's url.Values.
@@ -53,36 +67,101 @@ func (this *StrongParamsRequired) PostForm(request *http.Request) ReturnTarget {
 	return this.Values(request.PostForm)
 }
 
+// JSON instructs the mechanism to process the http.Request's JSON body. Look StrongParams.JSON for the details.
+func (this *StrongParamsRequired) JSON(request *http.Request) ReturnTarget {
+	return this.source(newJSONSourceValues(request, this.requestLimits))
+}
+
+// Multipart instructs the mechanism to stream the http.Request's multipart/form-data body. Look StrongParams.Multipart
+// for the details.
+func (this *StrongParamsRequired) Multipart(request *http.Request, limits MultipartLimits) ReturnTarget {
+	return this.source(newMultipartSourceValues(request, limits, func(key string) bool {
+		_, ok := this.transformKey(this.sourceKey(key))
+		return ok
+	}))
+}
+
+// Request instructs the mechanism to select the source of the parameters by the http.Request's method and
+// Content-Type header. Look StrongParams.Request for the details.
+func (this *StrongParamsRequired) Request(request *http.Request) ReturnTarget {
+	return this.source(this.requestSource(request, func(key string) bool {
+		_, ok := this.transformKey(this.sourceKey(key))
+		return ok
+	}))
+}
+
+// PathValues instructs the mechanism to process the http.Request's path parameters extracted by the `extractors`.
+// Look PathValues function for the details.
+func (this *StrongParamsRequired) PathValues(request *http.Request, extractors ...PathValueExtractor) ReturnTarget {
+	return this.source(newPathSourceValues(request, extractors))
+}
+
+// Headers instructs the mechanism to process the http.Request's headers. Look StrongParams.Headers for the details.
+func (this *StrongParamsRequired) Headers(request *http.Request) ReturnTarget {
+	return this.source(newHeaderSourceValues(request))
+}
+
+// Cookies instructs the mechanism to process the http.Request's cookies. Look StrongParams.Cookies for the details.
+func (this *StrongParamsRequired) Cookies(request *http.Request) ReturnTarget {
+	return this.source(newCookieSourceValues(request))
+}
+
+// Sources instructs the mechanism to merge the parameters of the `sources` in the declared order. Look
+// StrongParams.Sources for the details.
+func (this *StrongParamsRequired) Sources(sources ...Source) ReturnTarget {
+	return this.source(this.mergeSources(this.mergePolicy, sources, func(key string) bool {
+		_, ok := this.transformKey(this.sourceKey(key))
+		return ok
+	}))
+}
+
+// Raw instructs the mechanism to parse and process the `rawQuery` query string or application/x-www-form-urlencoded
+// body. Look StrongParams.Raw for the details.
+func (this *StrongParamsRequired) Raw(rawQuery string) ReturnTarget {
+	return this.source(newRawSourceValues(rawQuery, this.strictParsing))
+}
+
 // Values instructs the mechanism to process url.Values from `values` parameter.
 func (this *StrongParamsRequired) Values(values url.Values) ReturnTarget {
-	values = cloneUrlValues(values)
+	return this.source(newSourceValues(values))
+}
 
-	if this.error == nil {
-		this.error = this.validate(values)
+func (this *StrongParamsRequired) source(source *sourceValues) ReturnTarget {
+	source = this.normalizedArrays(this.prepared(source), nil)
+
+	if this.error == nil && source.error == nil {
+		this.error = this.validate(source.values)
 	}
 
 	return func(target interface{}) error {
-		if this.error != nil {
+		defer source.removeFiles()
+
+		if source.error != nil {
+			return source.error
+		} else if this.error != nil {
 			return this.error
 		} else if target == nil {
 			return errors.New("`target` argument cannot be nil")
 		}
 
-		return this.validateTransformAndDecode(values, target)
+		return this.validateTransformAndDecode(source, target)
 	}
 }
 
-func (this *strongParamsRequired) validateTransformAndDecode(values url.Values, target interface{}) (err error) {
-	if err := this.validate(values); err != nil {
+func (this *strongParamsRequired) validateTransformAndDecode(source *sourceValues, target interface{}) error {
+	if err := this.validate(source.values); err != nil {
 		return err
 	}
 
-	values, err = this.transform(values)
+	values, err := this.transform(source.values)
 	if err != nil {
 		return err
 	}
 
-	return this.decode(values, target)
+	transformed := source.transformed(values, this.transformKey)
+	transformed.transformPath = this.transformPath
+	transformed.untransformKey = this.untransformKey
+	return this.decode(transformed, target)
 }
 
 func (this *strongParamsRequired) validate(values url.Values) error {
@@ -99,9 +178,7 @@ func (this *strongParamsRequired) transform(values url.Values) (url.Values, erro
 	if this.requireKey != nil {
 		requiredQueryValues := make(url.Values)
 		for path, value := range values {
-			if strings.HasPrefix(path, *this.requireKey+"[") {
-				newPath := strings.Replace(path, *this.requireKey+"[", "", 1)
-				newPath = strings.Replace(newPath, "]", "", 1)
+			if newPath, ok := this.transformKey(path); ok {
 				requiredQueryValues[newPath] = make([]string, len(value))
 				copy(requiredQueryValues[newPath], value)
 			}
@@ -112,3 +189,34 @@ func (this *strongParamsRequired) transform(values url.Values) (url.Values, erro
 
 	return values, nil
 }
+
+// transformKey returns the `path` key relative to the required key or false if the key is not nested in the required
+// key.
+func (this *strongParamsRequired) transformKey(path string) (string, bool) {
+	if this.requireKey == nil {
+		return path, true
+	} else if !strings.HasPrefix(path, *this.requireKey+"[") {
+		return "", false
+	}
+
+	newPath := strings.Replace(path, *this.requireKey+"[", "", 1)
+	newPath = strings.Replace(newPath, "]", "", 1)
+	return newPath, true
+}
+
+// transformPath returns the `path` declared before applying Require relative to the required key. The required key
+// itself results in an empty path.
+func (this *strongParamsRequired) transformPath(path string) (string, bool) {
+	if this.requireKey != nil && path == *this.requireKey {
+		return "", true
+	}
+	return this.transformKey(path)
+}
+
+// untransformKey returns the `path` relative to the required key as the key before applying Require.
+func (this *strongParamsRequired) untransformKey(path string) string {
+	if this.requireKey == nil {
+		return path
+	}
+	return joinSegments(append([]string{*this.requireKey}, splitKey(path)...))
+}
@@ -2,9 +2,11 @@ package strongparams
 
 import (
 	"github.com/pkg/errors"
+	"github.com/thoas/go-funk"
 	"github.com/vellotis/go-strongparams/permitter"
 	"net/http"
 	"net/url"
+	"sort"
 )
 
 type StrongParamsRequiredAndPermitted struct {
@@ -13,12 +15,61 @@ type StrongParamsRequiredAndPermitted struct {
 
 type strongParamsRequiredAndPermitted struct {
 	*strongParamsRequired
-	permitRules permitter.Permittable
+	permitRules     permitter.Permittable
+	crossFieldRules []crossFieldRule
+	defaults        url.Values
 }
 
-// Query instructs the mechanism to process http.Request's url.URL property url.URL/Query() method returned url.Values.
+// parsePermitRules parses the `permitRules` into the permit rules, the cross-field rules and the default values
+// declared at the top level of the rules, eg. "email, phone, per_page=25, oneOf(email, phone)".
+func (this *strongParamsRequiredAndPermitted) parsePermitRules(permitRules []string) error {
+	var rules []string
+	this.defaults = url.Values{}
+
+	for _, permitRule := range funk.UniqString(permitRules) {
+		rule, crossFieldRules, err := extractCrossFieldRules(permitRule)
+		if err != nil {
+			return err
+		}
+		this.crossFieldRules = append(this.crossFieldRules, crossFieldRules...)
+
+		rule, defaults, err := extractDefaults(rule)
+		if err != nil {
+			return err
+		}
+		for key, values := range defaults {
+			this.defaults[key] = values
+		}
+
+		if rule != "" || rule == permitRule {
+			rules = append(rules, rule)
+		}
+	}
+
+	if len(rules) == 0 {
+		rules = append(rules, "")
+	}
+	var err error
+	if this.permitRules, err = permitter.ParsePermitted(rules...); err != nil {
+		return err
+	}
+
+	defaults := this.defaults
+	this.defaults = url.Values{}
+	for key, values := range defaults {
+		defaultKey, err := this.defaultKey(key)
+		if err != nil {
+			return err
+		}
+		this.defaults[defaultKey] = values
+	}
+	return nil
+}
+
+// Query instructs the mechanism to process http.Request's url.URL property's query string parsed as done by
+// url.URL/Query() method. The order of the pairs is preserved for the Rack style arrays of objects.
 func (this *StrongParamsRequiredAndPermitted) Query(request *http.Request) ReturnTarget {
-	return this.Values(request.URL.Query())
+	return this.source(newQuerySourceValues(request))
 }
 
 // PostForm instructs the mechanism to process http.Request's url.PostForm property's url.Values.
@@ -26,36 +77,115 @@ func (this *StrongParamsRequiredAndPermitted) PostForm(request *http.Request) Re
 	return this.Values(request.PostForm)
 }
 
+// JSON instructs the mechanism to process the http.Request's JSON body. Look StrongParams.JSON for the details.
+func (this *StrongParamsRequiredAndPermitted) JSON(request *http.Request) ReturnTarget {
+	return this.source(newJSONSourceValues(request, this.requestLimits))
+}
+
+// Multipart instructs the mechanism to stream the http.Request's multipart/form-data body. Look StrongParams.Multipart
+// for the details.
+func (this *StrongParamsRequiredAndPermitted) Multipart(request *http.Request, limits MultipartLimits) ReturnTarget {
+	return this.source(newMultipartSourceValues(request, limits, this.keepsKey))
+}
+
+// Request instructs the mechanism to select the source of the parameters by the http.Request's method and
+// Content-Type header. Look StrongParams.Request for the details.
+func (this *StrongParamsRequiredAndPermitted) Request(request *http.Request) ReturnTarget {
+	return this.source(this.requestSource(request, this.keepsKey))
+}
+
+// PathValues instructs the mechanism to process the http.Request's path parameters extracted by the `extractors`.
+// Look PathValues function for the details.
+func (this *StrongParamsRequiredAndPermitted) PathValues(request *http.Request,
+	extractors ...PathValueExtractor) ReturnTarget {
+
+	return this.source(newPathSourceValues(request, extractors))
+}
+
+// Headers instructs the mechanism to process the http.Request's headers. Look StrongParams.Headers for the details.
+func (this *StrongParamsRequiredAndPermitted) Headers(request *http.Request) ReturnTarget {
+	return this.source(newHeaderSourceValues(request))
+}
+
+// Cookies instructs the mechanism to process the http.Request's cookies. Look StrongParams.Cookies for the details.
+func (this *StrongParamsRequiredAndPermitted) Cookies(request *http.Request) ReturnTarget {
+	return this.source(newCookieSourceValues(request))
+}
+
+// Sources instructs the mechanism to merge the parameters of the `sources` in the declared order. Look
+// StrongParams.Sources for the details.
+func (this *StrongParamsRequiredAndPermitted) Sources(sources ...Source) ReturnTarget {
+	return this.source(this.mergeSources(this.mergePolicy, sources, this.keepsKey))
+}
+
+// Raw instructs the mechanism to parse and process the `rawQuery` query string or application/x-www-form-urlencoded
+// body. Look StrongParams.Raw for the details.
+func (this *StrongParamsRequiredAndPermitted) Raw(rawQuery string) ReturnTarget {
+	return this.source(newRawSourceValues(rawQuery, this.strictParsing))
+}
+
 // Values instructs the mechanism to process url.Values from `values` parameter.
 func (this *StrongParamsRequiredAndPermitted) Values(values url.Values) ReturnTarget {
-	values = cloneUrlValues(values)
+	return this.source(newSourceValues(values))
+}
 
-	if this.error == nil {
-		this.error = this.validate(values)
+func (this *StrongParamsRequiredAndPermitted) source(source *sourceValues) ReturnTarget {
+	source = this.normalizedArrays(this.prepared(source), this.isArrayKey)
+
+	if this.error == nil && source.error == nil {
+		this.error = this.validate(source.values)
 	}
 
 	return func(target interface{}) error {
-		if this.error != nil {
+		defer source.removeFiles()
+
+		if source.error != nil {
+			return source.error
+		} else if this.error != nil {
 			return this.error
 		} else if target == nil {
 			return errors.New("`target` argument cannot be nil")
 		}
 
-		return this.validateTransformAndDecode(values, target)
+		return this.validateTransformAndDecode(source, target)
 	}
 }
 
-func (this *StrongParamsRequiredAndPermitted) validateTransformAndDecode(values url.Values, target interface{}) (err error) {
-	if err := this.validate(values); err != nil {
+func (this *StrongParamsRequiredAndPermitted) validateTransformAndDecode(source *sourceValues,
+	target interface{}) error {
+
+	if err := this.validate(source.values); err != nil {
 		return err
 	}
 
-	values, err = this.transform(values)
+	values, err := this.permitted(source.values)
 	if err != nil {
 		return err
 	}
 
-	return this.decode(values, target)
+	values = this.withDefaults(values)
+
+	// The cross-field and the constraint violations are merged with the errors of the validator if declared
+	crossFieldErr := this.validateCrossFields(values)
+	if crossFieldErr != nil && this.validator == nil {
+		return crossFieldErr
+	}
+	constraintErr := this.validateValues(values)
+	if constraintErr != nil && this.validator == nil {
+		return constraintErr
+	}
+
+	transformed := source.transformed(values, this.transformKey)
+	transformed.transformPath = this.transformPath
+	transformed.untransformKey = this.untransformKey
+	if this.normalizeCollections {
+		transformed.collectionPositions = func(key string) []int {
+			positions, _ := this.permitRules.MatchCollection(key)
+			return positions
+		}
+	}
+
+	return mergeValidationErrors(crossFieldErr, constraintErr, this.decode(transformed, target))
 }
 
 func (this *strongParamsRequiredAndPermitted) validate(values url.Values) error {
@@ -67,16 +197,103 @@ func (this *strongParamsRequiredAndPermitted) validate(values url.Values) error
 }
 
 func (this *strongParamsRequiredAndPermitted) transform(queryValues url.Values) (_ url.Values, err error) {
+	queryValues, err = this.permitted(queryValues)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues = this.withDefaults(queryValues)
+	if err := this.validateCrossFields(queryValues); err != nil {
+		return nil, err
+	}
+
+	if err := this.validateValues(queryValues); err != nil {
+		return nil, err
+	}
+
+	return queryValues, nil
+}
+
+// permitted returns the `queryValues` relative to the required key whitelisted by the permit rules.
+func (this *strongParamsRequiredAndPermitted) permitted(queryValues url.Values) (_ url.Values, err error) {
 	queryValues, err = this.strongParamsRequired.transform(queryValues)
 	if err != nil {
 		return nil, err
 	}
 
 	for queryKeyPath := range queryValues {
-		if !this.permitRules.IsPermitted(queryKeyPath) {
+		if !this.isPermitted(queryKeyPath) {
 			queryValues.Del(queryKeyPath)
 		}
 	}
 
 	return queryValues, nil
 }
+
+// validateValues verifies the permitted `values` by the value constraints of the permit rules. The values are verified
+// as sanitized by the declared sanitizers.
+func (this *strongParamsRequiredAndPermitted) validateValues(values url.Values) error {
+	sanitized := this.sanitized(&sourceValues{values: values, transformPath: this.transformPath})
+
+	var validationErrors ValidationErrors
+	for path, value := range sanitized.values {
+		if err := this.permitRules.ValidateValues(path, value); err != nil {
+			validationErrors = append(validationErrors, &ValidationError{
+				Path:    this.untransformKey(path),
+				Message: err.Error(),
+			})
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		sort.Slice(validationErrors, func(i, j int) bool {
+			return validationErrors[i].Path < validationErrors[j].Path
+		})
+		return validationErrors
+	}
+	return nil
+}
+
+// transformKey returns the `path` key relative to the required key or false if the key is not nested in the required
+// key or is not permitted.
+func (this *strongParamsRequiredAndPermitted) transformKey(path string) (string, bool) {
+	newPath, ok := this.strongParamsRequired.transformKey(path)
+	if !ok || !this.isPermitted(newPath) {
+		return "", false
+	}
+	return newPath, true
+}
+
+// keepsKey returns whether the source `key` is permitted either as is or when normalized as an array key.
+func (this *strongParamsRequiredAndPermitted) keepsKey(key string) bool {
+	key = this.sourceKey(key)
+	if _, ok := this.transformKey(key); ok {
+		return true
+	}
+
+	base := key
+	if match := rgxArrayElementKey.FindStringSubmatch(key); match != nil {
+		base = match[1]
+	}
+	_, isConfigured := this.arrayFormats[base]
+	return isConfigured || this.isArrayKey(base+"[]")
+}
+
+// isArrayKey returns whether the `arrayKey` key, eg. "ids[]", is permitted as an array of values.
+func (this *strongParamsRequiredAndPermitted) isArrayKey(arrayKey string) bool {
+	if this.permitRules == nil {
+		return false
+	}
+	path, ok := this.strongParamsRequired.transformKey(arrayKey)
+	return ok && this.permitRules.IsPermitted(path)
+}
+
+// isPermitted verifies the `path` by the permit rules. The hash-keyed collection elements are permitted by the array
+// rules if the collections are normalized.
+func (this *strongParamsRequiredAndPermitted) isPermitted(path string) bool {
+	if this.normalizeCollections {
+		_, ok := this.permitRules.MatchCollection(path)
+		return ok
+	}
+	return this.permitRules.IsPermitted(path)
+}
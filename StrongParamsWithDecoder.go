@@ -3,11 +3,14 @@ package strongparams
 import (
 	"github.com/gorilla/schema"
 	"github.com/pkg/errors"
+	"github.com/vellotis/go-strongparams/permitter"
 )
 
+const defaultAliasTag = permitter.DefaultAliasTag
+
 var defaultDecoder = func() *schema.Decoder {
 	decoder := schema.NewDecoder()
-	decoder.SetAliasTag("params") // Use `params` tags instead of `schema`
+	decoder.SetAliasTag(defaultAliasTag) // Use `params` tags instead of `schema`
 	return decoder
 }()
 
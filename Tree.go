@@ -0,0 +1,192 @@
+package strongparams
+
+import (
+	"github.com/pkg/errors"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Tree is a generic nested representation of the processed parameters. It can be passed to ReturnTarget instead of a
+// struct pointer when there is no struct to decode into, eg. when the permitted parameters are forwarded to another
+// service or stored as JSON.
+//
+// The bracket notation keys are turned into nested values:
+//   - "key=value" results in a string value
+//   - "key=value1&key=value2" and "key[]=value" result in a []interface{} of string values
+//   - "key[sub]=value" results in a nested Tree
+//   - "key[0][sub]=value&key[1][sub]=value" results in an ordered []interface{} of nested Tree values
+//
+// The values of the sources preserving the value types, eg. StrongParams.JSON, keep their types: numbers are
+// json.Number values, booleans are bool values and nulls are nil values.
+type Tree map[string]interface{}
+
+type treeNode struct {
+	values   []interface{}
+	list     bool
+	children map[string]*treeNode
+}
+
+var rgxArrayIndex = regexp.MustCompile("^\\d+$")
+
+func buildTree(values url.Values, typed map[string][]interface{}) (Tree, error) {
+	root := &treeNode{}
+	values, err := groupEmptyBracketValues(values)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if _, isTyped := typed[key]; len(values[key]) == 0 && !isTyped {
+			continue
+		}
+		segments := unescapeKeySegments(splitKey(key))
+		if err := root.insert(key, segments, typedValues(typed, key, values[key])); err != nil {
+			return nil, err
+		}
+	}
+
+	tree, err := root.childrenTree("")
+	if err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+func (this *treeNode) insert(key string, segments []string, values []interface{}) error {
+	node := this
+	for idx, segment := range segments {
+		isLast := idx == len(segments)-1
+
+		if segment == "" && idx > 0 {
+			if !isLast {
+				return errors.Errorf("key `%s` contains `[]` before the last segment", key)
+			}
+			node.list = true
+			node.values = append(node.values, values...)
+			return nil
+		}
+
+		if node.children == nil {
+			node.children = map[string]*treeNode{}
+		}
+		child, ok := node.children[segment]
+		if !ok {
+			child = &treeNode{}
+			node.children[segment] = child
+		}
+		node = child
+
+		if isLast {
+			node.values = append(node.values, values...)
+		}
+	}
+
+	return nil
+}
+
+func (this *treeNode) value(path string) (interface{}, error) {
+	hasValues := this.values != nil || this.list
+	if hasValues && this.children != nil {
+		return nil, errors.Errorf("key `%s` is used both as a value and as a nested object", path)
+	}
+
+	switch {
+	case this.children != nil && this.isArray():
+		return this.childrenSlice(path)
+	case this.children != nil:
+		return this.childrenTree(path)
+	case !this.list && len(this.values) == 1:
+		return this.values[0], nil
+	default:
+		list := make([]interface{}, len(this.values))
+		copy(list, this.values)
+		return list, nil
+	}
+}
+
+func (this *treeNode) isArray() bool {
+	for segment := range this.children {
+		if !rgxArrayIndex.MatchString(segment) {
+			return false
+		}
+	}
+	return len(this.children) > 0
+}
+
+func (this *treeNode) childrenTree(path string) (Tree, error) {
+	tree := Tree{}
+	for segment, child := range this.children {
+		value, err := child.value(joinKey(path, segment))
+		if err != nil {
+			return nil, err
+		}
+		tree[segment] = value
+	}
+	return tree, nil
+}
+
+func (this *treeNode) childrenSlice(path string) ([]interface{}, error) {
+	segments := make([]string, 0, len(this.children))
+	for segment := range this.children {
+		segments = append(segments, segment)
+	}
+	sortIndexSegments(segments)
+
+	list := make([]interface{}, len(segments))
+	for idx, segment := range segments {
+		value, err := this.children[segment].value(joinKey(path, segment))
+		if err != nil {
+			return nil, err
+		}
+		list[idx] = value
+	}
+	return list, nil
+}
+
+// sortIndexSegments sorts the numeric array index segments by their numeric value.
+func sortIndexSegments(segments []string) {
+	sort.Slice(segments, func(i, j int) bool {
+		left, right := strings.TrimLeft(segments[i], "0"), strings.TrimLeft(segments[j], "0")
+		if len(left) != len(right) {
+			return len(left) < len(right)
+		}
+		return left < right
+	})
+}
+
+var rgxBracketKey = regexp.MustCompile("^[^\\[\\]]*(?:\\[[^\\[\\]]*\\])*$")
+
+// splitKey splits the bracket notation key to its segments, eg. "root[sub][0][]" results in
+// ["root", "sub", "0", ""]. Keys not following the bracket notation are returned as a single segment.
+func splitKey(key string) []string {
+	if !rgxBracketKey.MatchString(key) {
+		return []string{key}
+	}
+
+	idx := strings.IndexRune(key, '[')
+	if idx < 0 {
+		return []string{key}
+	}
+
+	segments := []string{key[:idx]}
+	for _, segment := range strings.Split(key[idx+1:len(key)-1], "][") {
+		segments = append(segments, segment)
+	}
+	return segments
+}
+
+// joinKey appends the `segment` to the bracket notation `key`.
+func joinKey(key string, segment string) string {
+	if key == "" {
+		return segment
+	}
+	return key + "[" + segment + "]"
+}
@@ -0,0 +1,29 @@
+package strongparams
+
+import (
+	"fmt"
+)
+
+// ValidationError describes a parameter which value violates a constraint, eg. the "age:int[0..150]" permit rule, or
+// a rule of the Validator declared by StrongParams.WithValidator.
+type ValidationError struct {
+	// Path is the bracket notation key of the parameter before applying Require, eg. "user[age]".
+	Path    string
+	Message string
+}
+
+func (this *ValidationError) Error() string {
+	return fmt.Sprintf("`%s` %s", this.Path, this.Message)
+}
+
+// ValidationErrors holds all the ValidationError errors of the processed parameters ordered by the paths. The single
+// errors are matched by errors.As.
+type ValidationErrors []*ValidationError
+
+func (this ValidationErrors) Error() string {
+	return errorListMessage("validation: ", this)
+}
+
+func (this ValidationErrors) Unwrap() []error {
+	return unwrapErrorList(this)
+}
@@ -0,0 +1,233 @@
+package strongparams
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Validator validates the target struct after it has been decoded by the ReturnTarget function. The `aliasTag` is the
+// struct tag the keys of the fields are resolved from. The returned ValidationErrors hold the bracket notation keys
+// relative to the required key, eg. "address[zip]", which are prefixed with the required key by the mechanism. The
+// other errors are returned as is.
+type Validator interface {
+	Validate(target interface{}, aliasTag string) error
+}
+
+// ValidatorFunc is an adapter to use an ordinary function as a Validator.
+type ValidatorFunc func(target interface{}, aliasTag string) error
+
+func (this ValidatorFunc) Validate(target interface{}, aliasTag string) error {
+	return this(target, aliasTag)
+}
+
+// StructValidator validates the fields of a struct, eg. *validator.Validate of github.com/go-playground/validator/v10.
+type StructValidator interface {
+	Struct(target interface{}) error
+}
+
+// playgroundFieldError declares the methods of validator.FieldError of github.com/go-playground/validator/v10 used to
+// remap the errors.
+type playgroundFieldError interface {
+	StructNamespace() string
+	Tag() string
+	Param() string
+}
+
+// PlaygroundValidator returns the Validator of the go-playground validator, eg. validator.New() of
+// github.com/go-playground/validator/v10. The field errors are remapped from the struct namespaces to the bracket
+// notation keys, eg. "UserInput.Address.Zip" to "address[zip]".
+//   Params().WithValidator(PlaygroundValidator(validator.New())).
+//       Require("user").Permit("address:{zip}").Query(request)(&input)
+//   // err: validation: `user[address][zip]` failed on the `required` rule
+// The errors which are not a list of field errors, eg. validator.InvalidValidationError, are returned as is. The
+// validator package is not imported: the field errors are matched by the playgroundFieldError methods of
+// validator.FieldError.
+func PlaygroundValidator(validate StructValidator) Validator {
+	return ValidatorFunc(func(target interface{}, aliasTag string) error {
+		err := validate.Struct(target)
+		if err == nil {
+			return nil
+		}
+
+		fieldErrors := reflect.ValueOf(err)
+		if fieldErrors.Kind() != reflect.Slice {
+			return err
+		}
+
+		validationErrors := make(ValidationErrors, 0, fieldErrors.Len())
+		for idx := 0; idx < fieldErrors.Len(); idx++ {
+			fieldError, ok := fieldErrors.Index(idx).Interface().(playgroundFieldError)
+			if !ok {
+				return err
+			}
+
+			rule := fieldError.Tag()
+			if fieldError.Param() != "" {
+				rule += "=" + fieldError.Param()
+			}
+			validationErrors = append(validationErrors, &ValidationError{
+				Path:    namespaceKey(reflect.TypeOf(target), fieldError.StructNamespace(), aliasTag),
+				Message: fmt.Sprintf("failed on the `%s` rule", rule),
+			})
+		}
+
+		if len(validationErrors) == 0 {
+			return nil
+		}
+		return validationErrors
+	})
+}
+
+var rgxNamespaceSegment = regexp.MustCompile("^([^\\[\\]]+)((?:\\[[^\\[\\]]*\\])*)$")
+
+// namespaceKey returns the bracket notation key of the `namespace` struct namespace, eg. "UserInput.Items[0].Name", of
+// the `targetType` struct. The field names are replaced with the keys resolved from the `aliasTag` struct tag and the
+// embedded structs without an explicit key are omitted. The unresolvable segments are kept as they are.
+func namespaceKey(targetType reflect.Type, namespace string, aliasTag string) string {
+	names := strings.Split(namespace, ".")
+	if len(names) > 1 {
+		names = names[1:]
+	}
+
+	var segments []string
+	fieldType := targetType
+	for _, name := range names {
+		match := rgxNamespaceSegment.FindStringSubmatch(name)
+		if match == nil {
+			segments = append(segments, name)
+			fieldType = nil
+			continue
+		}
+
+		for fieldType != nil && fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType != nil && fieldType.Kind() == reflect.Struct {
+			if field, ok := fieldType.FieldByName(match[1]); ok {
+				structType := fieldType
+				for _, fieldIdx := range field.Index {
+					for structType.Kind() == reflect.Ptr {
+						structType = structType.Elem()
+					}
+					indexedField := structType.Field(fieldIdx)
+					if key, ok := fieldKey(indexedField, aliasTag); ok {
+						segments = append(segments, key)
+					}
+					structType = indexedField.Type
+				}
+				fieldType = field.Type
+			} else {
+				segments = append(segments, match[1])
+				fieldType = nil
+			}
+		} else {
+			segments = append(segments, match[1])
+			fieldType = nil
+		}
+
+		if match[2] != "" {
+			for _, index := range strings.Split(strings.Trim(match[2], "[]"), "][") {
+				segments = append(segments, index)
+				for fieldType != nil && fieldType.Kind() == reflect.Ptr {
+					fieldType = fieldType.Elem()
+				}
+				if fieldType != nil {
+					switch fieldType.Kind() {
+					case reflect.Slice, reflect.Array, reflect.Map:
+						fieldType = fieldType.Elem()
+					default:
+						fieldType = nil
+					}
+				}
+			}
+		}
+	}
+
+	return joinSegments(segments)
+}
+
+// fieldKey returns the key of the struct `field` resolved from the `aliasTag` struct tag or false if the field is an
+// embedded struct without an explicit key.
+func fieldKey(field reflect.StructField, aliasTag string) (string, bool) {
+	tag := strings.Split(field.Tag.Get(aliasTag), ",")[0]
+	if tag != "" && tag != "-" {
+		return tag, true
+	}
+
+	fieldType := field.Type
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	if field.Anonymous && tag == "" && fieldType.Kind() == reflect.Struct {
+		return "", false
+	}
+	return field.Name, true
+}
+
+// WithValidator declares the `validator` to validate the target structs after decoding. The ValidationErrors of the
+// validator are merged with the violations of the permit rule constraints and reported by the keys before applying
+// Require. The option will be used on the returned *StrongParams struct pointer not on the receiver parameter.
+func (this *StrongParams) WithValidator(validator Validator) *StrongParams {
+	params := this.clone()
+	params.validator = validator
+	return params
+}
+
+// validated validates the decoded `target` struct by the declared validator. The paths of the ValidationErrors are
+// untransformed by `untransformKey` if declared.
+func (this *strongParams) validated(target interface{}, aliasTag string,
+	untransformKey func(path string) string) error {
+
+	if this.validator == nil {
+		return nil
+	}
+
+	err := this.validator.Validate(target, aliasTag)
+	validationErrors, ok := err.(ValidationErrors)
+	if !ok || untransformKey == nil {
+		return err
+	}
+
+	for _, validationError := range validationErrors {
+		validationError.Path = untransformKey(validationError.Path)
+	}
+	return validationErrors
+}
+
+// mergeValidationErrors merges the ValidationErrors of the `errs` ordered by the paths. The errors of the other types,
+// eg. CrossFieldErrors or a decoding error, are joined with the merged ValidationErrors so all are matched by
+// errors.As.
+func mergeValidationErrors(errs ...error) error {
+	var merged ValidationErrors
+	var joined []error
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		validationErrors, ok := err.(ValidationErrors)
+		if !ok {
+			joined = append(joined, err)
+			continue
+		}
+		merged = append(merged, validationErrors...)
+	}
+
+	if len(merged) > 0 {
+		sort.SliceStable(merged, func(i, j int) bool {
+			return merged[i].Path < merged[j].Path
+		})
+		joined = append(joined, merged)
+	}
+
+	switch len(joined) {
+	case 0:
+		return nil
+	case 1:
+		return joined[0]
+	}
+	return errors.Join(joined...)
+}
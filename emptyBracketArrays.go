@@ -0,0 +1,241 @@
+package strongparams
+
+import (
+	"github.com/pkg/errors"
+	"mime/multipart"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// rgxEmptyBracketsObject matches the Rack style array of objects, eg. "[][name]" of "items[][name]".
+var rgxEmptyBracketsObject = regexp.MustCompile(`\[\]\[[^\[\]]`)
+
+func hasEmptyBracketsObject(key string) bool {
+	return rgxEmptyBracketsObject.MatchString(key)
+}
+
+// emptyBracketGrouper resolves the Rack style arrays of objects of the ordered pairs into the indexed keys as done by
+// Rack: the key is added to the last object of the array unless the object already holds the key. Otherwise a new
+// object is started. The keys of the nested arrays, eg. "items[][tags][]", are always added to the last object.
+//   items[][name]=a&items[][tags][]=x&items[][name]=b&items[][price]=2
+//   Grouped as: items[0][name]=a&items[0][tags][]=x&items[1][name]=b&items[1][price]=2
+type emptyBracketGrouper struct {
+	arrays map[string][]map[string]bool
+}
+
+func newEmptyBracketGrouper() *emptyBracketGrouper {
+	return &emptyBracketGrouper{arrays: map[string][]map[string]bool{}}
+}
+
+// key returns the indexed key of the next pair of the `key`. The keys have to be passed in the order of the pairs.
+func (this *emptyBracketGrouper) key(key string) string {
+	for {
+		loc := rgxEmptyBracketsObject.FindStringIndex(key)
+		if loc == nil {
+			return key
+		}
+
+		prefix, rest := key[:loc[0]], key[loc[0]+2:]
+		objects := this.arrays[prefix]
+		isArray := strings.Contains(rest, "[]")
+		if len(objects) == 0 || !isArray && objects[len(objects)-1][rest] {
+			objects = append(objects, map[string]bool{})
+			this.arrays[prefix] = objects
+		}
+		if object := objects[len(objects)-1]; !isArray {
+			// The parent keys are held as well, eg. "[address]" of "[address][city]"
+			for idx := 1; idx < len(rest); idx++ {
+				if rest[idx] == '[' {
+					object[rest[:idx]] = true
+				}
+			}
+			object[rest] = true
+		}
+
+		key = prefix + "[" + strconv.Itoa(len(objects)-1) + "]" + rest
+	}
+}
+
+// groupedRawValues returns the ordered `params` as url.Values with the Rack style arrays of objects resolved into the
+// indexed keys. Look emptyBracketGrouper for the details.
+func groupedRawValues(params RawParams) url.Values {
+	grouper := newEmptyBracketGrouper()
+	values := make(url.Values, len(params))
+	for _, param := range params {
+		key := grouper.key(param.Key)
+		values[key] = append(values[key], param.Value)
+	}
+	return values
+}
+
+// groupEmptyBracketKeys resolves the Rack style arrays of objects of the unordered keys into the indexed keys. The
+// `counts` parameter holds the amount of values of the keys. The returned map holds the indexed key of every value of
+// the keys containing an array of objects while the other keys are omitted. url.Values doesn't preserve the order of
+// the keys so only the arrays grouped the same in any order are resolved: the arrays holding a single object, eg.
+// "items[][name]=a&items[][price]=1", and the arrays of a single key, eg. "items[][name]=a&items[][name]=b".
+// Otherwise an error is returned instead of guessing the objects.
+func groupEmptyBracketKeys(counts map[string]int) (map[string][]string, error) {
+	var keys []string
+	for key, count := range counts {
+		if hasEmptyBracketsObject(key) && count > 0 {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	if err := verifyUnorderedEmptyBrackets(counts); err != nil {
+		return nil, err
+	}
+
+	sort.Strings(keys)
+	grouper := newEmptyBracketGrouper()
+	grouped := make(map[string][]string, len(keys))
+	for _, key := range keys {
+		for idx := 0; idx < counts[key]; idx++ {
+			grouped[key] = append(grouped[key], grouper.key(key))
+		}
+	}
+	return grouped, nil
+}
+
+// verifyUnorderedEmptyBrackets returns an error if the objects of any Rack style array of the keys depend on the order
+// of the keys.
+func verifyUnorderedEmptyBrackets(counts map[string]int) error {
+	for len(counts) > 0 {
+		arrays := map[string]map[string]int{}
+		for key, count := range counts {
+			loc := rgxEmptyBracketsObject.FindStringIndex(key)
+			if loc == nil || count == 0 {
+				continue
+			}
+			prefix := key[:loc[0]]
+			if arrays[prefix] == nil {
+				arrays[prefix] = map[string]int{}
+			}
+			arrays[prefix][key[loc[0]+2:]] += count
+		}
+
+		nested := map[string]int{}
+		for prefix, rests := range arrays {
+			var keys, repeated int
+			var hasArray bool
+			for rest, count := range rests {
+				if strings.Contains(rest, "[]") {
+					hasArray = true
+					// The keys of the nested arrays are added to the single object
+					nested[prefix+"[0]"+rest] += count
+					continue
+				}
+				keys++
+				if count > 1 {
+					repeated++
+				}
+			}
+			if repeated > 0 && (keys > 1 || hasArray) {
+				return errors.Errorf("the objects of array `%s[]` depend on the order of the keys which is not "+
+					"preserved by url.Values", prefix)
+			}
+		}
+		counts = nested
+	}
+	return nil
+}
+
+// groupEmptyBracketValues returns the `values` with the Rack style arrays of objects resolved into the indexed keys.
+// Look groupEmptyBracketKeys for the details.
+func groupEmptyBracketValues(values url.Values) (url.Values, error) {
+	counts := make(map[string]int, len(values))
+	for key, value := range values {
+		counts[key] = len(value)
+	}
+	grouped, err := groupEmptyBracketKeys(counts)
+	if err != nil || len(grouped) == 0 {
+		return values, err
+	}
+
+	result := make(url.Values, len(values))
+	for key, value := range values {
+		if !hasEmptyBracketsObject(key) {
+			result[key] = append(result[key], value...)
+			continue
+		}
+		for idx, groupedKey := range grouped[key] {
+			result[groupedKey] = append(result[groupedKey], value[idx])
+		}
+	}
+	return result, nil
+}
+
+// grouped returns the sourceValues with the Rack style arrays of objects of the values and the files resolved into
+// the indexed keys. The sources preserving the order of the pairs are grouped when loaded so only the unordered
+// url.Values are resolved here. Look groupEmptyBracketKeys for the details.
+func (this *sourceValues) grouped() (*sourceValues, error) {
+	counts := make(map[string]int, len(this.values))
+	for key, value := range this.values {
+		counts[key] = len(value)
+	}
+	groupedValues, err := groupEmptyBracketKeys(counts)
+	if err != nil {
+		return nil, err
+	}
+
+	counts = make(map[string]int, len(this.files))
+	for key, value := range this.files {
+		counts[key] = len(value)
+	}
+	groupedFiles, err := groupEmptyBracketKeys(counts)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(groupedValues) == 0 && len(groupedFiles) == 0 {
+		return this, nil
+	}
+
+	grouped := *this
+	grouped.values = make(url.Values, len(this.values))
+	grouped.origins = nil
+	if this.origins != nil {
+		grouped.origins = make(map[string]string, len(this.origins))
+	}
+	setOrigin := func(key string, groupedKey string) {
+		if origin, ok := this.origins[key]; ok {
+			grouped.origins[groupedKey] = origin
+		}
+	}
+
+	for key, value := range this.values {
+		if !hasEmptyBracketsObject(key) {
+			grouped.values[key] = append(grouped.values[key], value...)
+			setOrigin(key, key)
+			continue
+		}
+		for idx, groupedKey := range groupedValues[key] {
+			grouped.values[groupedKey] = append(grouped.values[groupedKey], value[idx])
+			setOrigin(key, groupedKey)
+		}
+	}
+
+	if len(groupedFiles) != 0 {
+		grouped.files = make(map[string][]*multipart.FileHeader, len(this.files))
+		for key, value := range this.files {
+			if !hasEmptyBracketsObject(key) {
+				grouped.files[key] = append(grouped.files[key], value...)
+				continue
+			}
+			for idx, groupedKey := range groupedFiles[key] {
+				grouped.files[groupedKey] = append(grouped.files[groupedKey], value[idx])
+				if _, ok := grouped.values[groupedKey]; !ok {
+					grouped.values[groupedKey] = []string{}
+				}
+				setOrigin(key, groupedKey)
+			}
+		}
+	}
+
+	return &grouped, nil
+}
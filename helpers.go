@@ -2,18 +2,24 @@ package strongparams
 
 import (
 	"net/url"
+	"reflect"
+	"strconv"
 	"strings"
 )
 
 func hasKey(queryValues url.Values, requireKey string) bool {
 	for key := range queryValues {
-		if key == requireKey || strings.HasPrefix(key, requireKey+"[") {
+		if isKeyUnder(key, requireKey) {
 			return true
 		}
 	}
 	return false
 }
 
+func isKeyUnder(key string, rootKey string) bool {
+	return key == rootKey || strings.HasPrefix(key, rootKey+"[")
+}
+
 func cloneUrlValues(queryValues url.Values) url.Values {
 	newQueryValues := make(url.Values)
 	for key, value := range queryValues {
@@ -22,3 +28,112 @@ func cloneUrlValues(queryValues url.Values) url.Values {
 	}
 	return newQueryValues
 }
+
+// fieldByKey resolves the struct field addressed by the bracket notation key `segments` starting from the `value`
+// struct or pointer to a struct. The struct fields are resolved by the `aliasTag` struct tag and the nil pointers on
+// the way are allocated. Numeric segments address the existing elements of slices.
+func fieldByKey(value reflect.Value, segments []string, aliasTag string) (reflect.Value, bool) {
+	for _, segment := range segments {
+		for value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				if !value.CanSet() {
+					return reflect.Value{}, false
+				}
+				value.Set(reflect.New(value.Type().Elem()))
+			}
+			value = value.Elem()
+		}
+
+		switch value.Kind() {
+		case reflect.Struct:
+			fieldIndex, ok := fieldIndexByAlias(value.Type(), segment, aliasTag)
+			if !ok {
+				return reflect.Value{}, false
+			}
+			value = value.FieldByIndex(fieldIndex)
+
+		case reflect.Slice, reflect.Array:
+			idx, err := strconv.Atoi(segment)
+			if err != nil || idx < 0 || idx >= value.Len() {
+				return reflect.Value{}, false
+			}
+			value = value.Index(idx)
+
+		default:
+			return reflect.Value{}, false
+		}
+	}
+
+	return value, value.CanSet()
+}
+
+// fieldTypeByKey resolves the type of the struct field addressed by the `segments` starting from the `fieldType` struct
+// or pointer to a struct. Numeric segments address the elements of slices.
+func fieldTypeByKey(fieldType reflect.Type, segments []string, aliasTag string) (reflect.Type, bool) {
+	for _, segment := range segments {
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		switch fieldType.Kind() {
+		case reflect.Struct:
+			fieldIndex, ok := fieldIndexByAlias(fieldType, segment, aliasTag)
+			if !ok {
+				return nil, false
+			}
+			fieldType = fieldType.FieldByIndex(fieldIndex).Type
+
+		case reflect.Slice, reflect.Array:
+			if _, err := strconv.Atoi(segment); err != nil {
+				return nil, false
+			}
+			fieldType = fieldType.Elem()
+
+		default:
+			return nil, false
+		}
+	}
+
+	return fieldType, true
+}
+
+// fieldIndexByAlias returns the index of the `structType` field with the `alias` key resolved by the `aliasTag` struct
+// tag. The fields of the embedded structs without an explicit key are promoted.
+func fieldIndexByAlias(structType reflect.Type, alias string, aliasTag string) ([]int, bool) {
+	for idx := 0; idx < structType.NumField(); idx++ {
+		field := structType.Field(idx)
+		tag := strings.Split(field.Tag.Get(aliasTag), ",")[0]
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		if field.Anonymous && tag == "" && fieldType.Kind() == reflect.Struct && field.Type.Kind() != reflect.Ptr {
+			if subIndex, ok := fieldIndexByAlias(fieldType, alias, aliasTag); ok {
+				return append([]int{idx}, subIndex...), true
+			}
+		} else if field.PkgPath == "" && tag != "-" && (tag == alias || (tag == "" && field.Name == alias)) {
+			return []int{idx}, true
+		}
+	}
+	return nil, false
+}
+
+// errorListMessage returns the messages of the `errs` list joined by "; " and prefixed by the `prefix`.
+func errorListMessage[T error](prefix string, errs []T) string {
+	messages := make([]string, len(errs))
+	for idx, err := range errs {
+		messages[idx] = err.Error()
+	}
+	return prefix + strings.Join(messages, "; ")
+}
+
+// unwrapErrorList returns the single errors of the `errs` list to be matched by errors.Is and errors.As.
+func unwrapErrorList[T error](errs []T) []error {
+	unwrapped := make([]error, len(errs))
+	for idx, err := range errs {
+		unwrapped[idx] = err
+	}
+	return unwrapped
+}
@@ -0,0 +1,25 @@
+// Package types holds the type inspection shared by the strongparams and the permitter packages.
+package types
+
+import (
+	"encoding"
+	"reflect"
+)
+
+var textUnmarshalerInterface = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// IsScalar returns whether the values of the `fieldType` type are decoded from a single value: the booleans, the
+// strings, the numbers and the types implementing encoding.TextUnmarshaler, eg. time.Time or net.IP.
+func IsScalar(fieldType reflect.Type) bool {
+	if fieldType.Implements(textUnmarshalerInterface) || reflect.PtrTo(fieldType).Implements(textUnmarshalerInterface) {
+		return true
+	}
+
+	switch fieldType.Kind() {
+	case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
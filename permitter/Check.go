@@ -0,0 +1,185 @@
+package permitter
+
+import (
+	"fmt"
+	"github.com/vellotis/go-strongparams/internal/types"
+	"reflect"
+	"sort"
+)
+
+// MismatchKind classifies the inconsistencies found by Check.
+type MismatchKind int
+
+const (
+	// UnknownField marks a permitted path without a matching struct field.
+	UnknownField MismatchKind = iota
+	// RequiredNotPermitted marks a `required` struct field which is dropped by the rules.
+	RequiredNotPermitted
+	// ShapeMismatch marks a permitted path which declares an object, an array or a value while the matching struct
+	// field is of a different shape.
+	ShapeMismatch
+)
+
+// Mismatch describes an inconsistency between the whitelisting rules and a struct type.
+type Mismatch struct {
+	Kind MismatchKind
+	// Path is the bracket notation path of the inconsistency, eg. "address[street]" or "items[][name]".
+	Path    string
+	Message string
+}
+
+func (this Mismatch) String() string {
+	return fmt.Sprintf("`%s`: %s", this.Path, this.Message)
+}
+
+// Check verifies that the whitelisting rules `rules` are consistent with the struct type `structType` and returns the
+// found inconsistencies. The keys of the struct fields are resolved from the DefaultAliasTag struct tag. Following is
+// reported:
+//   • permitted paths without a matching struct field
+//   • struct fields tagged as `required`, eg. `params:"key,required"`, which are not permitted
+//   • permitted objects, arrays and values that don't match the shape of the struct field
+// A value rule is considered to match a slice of values as repeated keys, eg. "key=value1&key=value2", decode into a
+// slice.
+func Check(rules Permittable, structType reflect.Type) []Mismatch {
+	return CheckWithAliasTag(rules, structType, DefaultAliasTag)
+}
+
+// CheckWithAliasTag is equivalent to Check but resolves the keys of the struct fields from the `aliasTag` struct tag.
+func CheckWithAliasTag(rules Permittable, structType reflect.Type, aliasTag string) []Mismatch {
+	checker := typeChecker{
+		aliasTag: aliasTag,
+		visiting: map[reflect.Type]bool{},
+	}
+	checker.check(rules, structType, "")
+	return checker.mismatches
+}
+
+type typeChecker struct {
+	aliasTag   string
+	visiting   map[reflect.Type]bool
+	mismatches []Mismatch
+}
+
+func (this *typeChecker) check(rule permittable, fieldType reflect.Type, path string) {
+	fieldType = indirectType(fieldType)
+
+	switch typedRule := rule.(type) {
+	case *objElement:
+		this.checkObject(typedRule, fieldType, path)
+
+	case *arrayElement:
+		this.checkArray(typedRule, fieldType, path)
+
+	case *mapElement:
+		if fieldType.Kind() != reflect.Map {
+			this.mismatch(ShapeMismatch, path, "rule declares an object but the field is `%s`", fieldType)
+			return
+		}
+		this.check(typedRule.value, fieldType.Elem(), joinPath(path, ""))
+
+	default:
+		isValue := types.IsScalar(fieldType)
+		isValueSlice := (fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Array) &&
+			types.IsScalar(indirectType(fieldType.Elem()))
+		if !isValue && !isValueSlice {
+			this.mismatch(ShapeMismatch, path, "rule declares a value but the field is `%s`", fieldType)
+		}
+	}
+}
+
+func (this *typeChecker) checkObject(rule *objElement, fieldType reflect.Type, path string) {
+	switch {
+	case types.IsScalar(fieldType):
+		this.mismatch(ShapeMismatch, path, "rule declares an object but the field is `%s`", fieldType)
+
+	case fieldType.Kind() == reflect.Map:
+		for _, key := range rule.sortedKeys() {
+			this.check((*rule)[key], fieldType.Elem(), joinPath(path, string(key)))
+		}
+
+	case fieldType.Kind() == reflect.Struct:
+		if this.visiting[fieldType] {
+			return
+		}
+		this.visiting[fieldType] = true
+		defer delete(this.visiting, fieldType)
+
+		fields := map[permitKeyElement]structField{}
+		for _, field := range structFields(fieldType, this.aliasTag) {
+			fields[permitKeyElement(field.alias)] = field
+		}
+
+		for _, key := range rule.sortedKeys() {
+			field, ok := fields[key]
+			if !ok {
+				this.mismatch(UnknownField, joinPath(path, string(key)), "no matching field in `%s`", fieldType)
+				continue
+			}
+			this.check((*rule)[key], field.Type, joinPath(path, string(key)))
+		}
+
+		for _, field := range structFields(fieldType, this.aliasTag) {
+			if _, ok := (*rule)[permitKeyElement(field.alias)]; !ok && field.isRequired() {
+				this.mismatch(RequiredNotPermitted, joinPath(path, field.alias),
+					"required field `%s.%s` is not permitted", fieldType, field.Name)
+			}
+		}
+
+	default:
+		this.mismatch(ShapeMismatch, path, "rule declares an object but the field is `%s`", fieldType)
+	}
+}
+
+func (this *typeChecker) checkArray(rule *arrayElement, fieldType reflect.Type, path string) {
+	if fieldType.Kind() != reflect.Slice && fieldType.Kind() != reflect.Array {
+		this.mismatch(ShapeMismatch, path, "rule declares an array but the field is `%s`", fieldType)
+		return
+	}
+
+	elemType := indirectType(fieldType.Elem())
+	if len(*rule) == 0 {
+		if !types.IsScalar(elemType) {
+			this.mismatch(ShapeMismatch, path, "rule declares an array of values but the field is `%s`", fieldType)
+		}
+		return
+	}
+
+	for _, subRule := range *rule {
+		this.check(subRule, elemType, joinPath(path, ""))
+	}
+}
+
+func (this *typeChecker) mismatch(kind MismatchKind, path string, format string, args ...interface{}) {
+	this.mismatches = append(this.mismatches, Mismatch{
+		Kind:    kind,
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (this *objElement) sortedKeys() []permitKeyElement {
+	keys := make([]permitKeyElement, 0, len(*this))
+	for key := range *this {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+func (this structField) isRequired() bool {
+	for _, option := range this.options {
+		if option == "required" {
+			return true
+		}
+	}
+	return false
+}
+
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "[" + key + "]"
+}
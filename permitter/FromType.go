@@ -0,0 +1,209 @@
+package permitter
+
+import (
+	"github.com/pkg/errors"
+	"github.com/vellotis/go-strongparams/internal/types"
+	"reflect"
+	"strings"
+)
+
+// DefaultAliasTag is the struct tag used by FromType to resolve the keys of the struct fields. It equals to the alias
+// tag of the default schema.Decoder used by the strongparams package.
+const DefaultAliasTag = "params"
+
+// PermitTag is the struct tag that controls whether a struct field is included in the rules built by FromType:
+//   • `permit:"-"` excludes the field
+//   • `permit:"admin,owner"` includes the field only if any of the listed roles is passed to FromType
+// Fields without the tag are always included.
+const PermitTag = "permit"
+
+// FromType builds the whitelisting rules from the struct type `structType` or returns an error. The keys are resolved
+// from the DefaultAliasTag struct tag and fall back to the field names. Nested structs, pointers, slices, arrays and
+// maps with string keys are walked recursively:
+//   type Address struct {
+//       Street string `params:"street"`
+//   }
+//   type User struct {
+//       Name      string            `params:"name"`
+//       Tags      []string          `params:"tags"`
+//       Addresses []Address         `params:"addresses"`
+//       Meta      map[string]string `params:"meta"`
+//       Admin     bool              `params:"admin" permit:"admin"`
+//       Password  string            `params:"password" permit:"-"`
+//   }
+//   FromType(reflect.TypeOf(User{}))
+// is equivalent to the rule "name, tags:[], addresses:[street]" additionally permitting any key nested in `meta`, eg.
+// "meta[anyKey]". And
+//   FromType(reflect.TypeOf(User{}), "admin")
+// additionally permits the `admin` key.
+func FromType(structType reflect.Type, roles ...string) (Permittable, error) {
+	return FromTypeWithAliasTag(structType, DefaultAliasTag, roles...)
+}
+
+// FromTypeWithAliasTag is equivalent to FromType but resolves the keys of the struct fields from the `aliasTag` struct
+// tag.
+func FromTypeWithAliasTag(structType reflect.Type, aliasTag string, roles ...string) (Permittable, error) {
+	if structType == nil {
+		return nil, errors.New("`structType` parameter cannot be `nil`")
+	}
+
+	structType = indirectType(structType)
+	if structType.Kind() != reflect.Struct {
+		return nil, errors.Errorf("type `%s` is not a struct", structType)
+	}
+
+	builder := typeRuleBuilder{
+		aliasTag: aliasTag,
+		roles:    roles,
+		visiting: map[reflect.Type]bool{},
+	}
+	obj, err := builder.buildStruct(structType)
+	if err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+type typeRuleBuilder struct {
+	aliasTag string
+	roles    []string
+	visiting map[reflect.Type]bool
+}
+
+func (this *typeRuleBuilder) build(fieldType reflect.Type) (permittable, error) {
+	fieldType = indirectType(fieldType)
+
+	switch {
+	case types.IsScalar(fieldType):
+		key := permitKeyElement("")
+		return &key, nil
+
+	case fieldType.Kind() == reflect.Struct:
+		return this.buildStruct(fieldType)
+
+	case fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Array:
+		if types.IsScalar(indirectType(fieldType.Elem())) {
+			return &arrayElement{}, nil
+		}
+		elem, err := this.build(fieldType.Elem())
+		if err != nil || elem == nil {
+			return nil, err
+		}
+		return &arrayElement{elem}, nil
+
+	case fieldType.Kind() == reflect.Map && fieldType.Key().Kind() == reflect.String:
+		value, err := this.build(fieldType.Elem())
+		if err != nil || value == nil {
+			return nil, err
+		}
+		return &mapElement{value: value}, nil
+	}
+
+	return nil, nil
+}
+
+func (this *typeRuleBuilder) buildStruct(structType reflect.Type) (*objElement, error) {
+	if this.visiting[structType] {
+		return nil, errors.Errorf("type `%s` is recursive", structType)
+	}
+	this.visiting[structType] = true
+	defer delete(this.visiting, structType)
+
+	obj := objElement{}
+	for _, field := range structFields(structType, this.aliasTag) {
+		if !this.isFieldPermitted(field.StructField) {
+			continue
+		}
+
+		elem, err := this.build(field.Type)
+		if err != nil {
+			return nil, errors.Wrapf(err, "field `%s.%s`", structType, field.Name)
+		}
+		if elem == nil {
+			continue
+		}
+
+		key := permitKeyElement(field.alias)
+		if leaf, ok := elem.(*permitKeyElement); ok {
+			*leaf = key
+		}
+		obj[key] = elem
+	}
+
+	return &obj, nil
+}
+
+func (this *typeRuleBuilder) isFieldPermitted(field reflect.StructField) bool {
+	tag, hasTag := field.Tag.Lookup(PermitTag)
+	if !hasTag || tag == "" {
+		return true
+	}
+
+	for _, role := range strings.Split(tag, ",") {
+		role = strings.TrimSpace(role)
+		if role == "-" {
+			return false
+		}
+		for _, permittedRole := range this.roles {
+			if role == permittedRole {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type structField struct {
+	reflect.StructField
+	alias   string
+	options []string
+}
+
+// structFields returns the decodable fields of the struct type `structType` with the keys resolved from the
+// `aliasTag` struct tag. The fields of the embedded structs without an explicit key are promoted.
+func structFields(structType reflect.Type, aliasTag string) []structField {
+	var fields []structField
+
+	for idx := 0; idx < structType.NumField(); idx++ {
+		field := structType.Field(idx)
+		alias, options := fieldAlias(field, aliasTag)
+
+		switch {
+		case alias == "-":
+			continue
+
+		case field.Anonymous && indirectType(field.Type).Kind() == reflect.Struct && field.Tag.Get(aliasTag) == "":
+			fields = append(fields, structFields(indirectType(field.Type), aliasTag)...)
+
+		case field.PkgPath != "":
+			continue
+
+		default:
+			fields = append(fields, structField{
+				StructField: field,
+				alias:       alias,
+				options:     options,
+			})
+		}
+	}
+
+	return fields
+}
+
+func fieldAlias(field reflect.StructField, aliasTag string) (alias string, options []string) {
+	if tag := field.Tag.Get(aliasTag); tag != "" {
+		parts := strings.Split(tag, ",")
+		alias, options = parts[0], parts[1:]
+	}
+	if alias == "" {
+		alias = field.Name
+	}
+	return alias, options
+}
+
+func indirectType(fieldType reflect.Type) reflect.Type {
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	return fieldType
+}
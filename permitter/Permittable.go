@@ -14,13 +14,20 @@ type Permittable interface {
 	permittable
 	// IsPermitted verifies that the URL query path is permitted by the permitter rules and returns the boolean result.
 	IsPermitted(path string) bool
+	// MatchCollection verifies the path like IsPermitted but additionally matches the hash keys, eg. "abc" of
+	// "items[abc][name]", as the elements of the array rules. It returns the indexes of the path segments matched as
+	// the array elements, eg. [1] for "items[abc][name]" matched by "items:[name]".
+	MatchCollection(path string) ([]int, bool)
+	// ValidateValues verifies the values of the permitted path by the value constraints of the rules and returns the
+	// error describing the violation. The empty values are not constrained.
+	ValidateValues(path string, values []string) error
 }
 
 type permittable interface {
 	isPermitted(rgxResult [][]string) bool
 }
 
-var rgxQueryPathFull = regexp.MustCompile("^(?:[^\\[\\]]+)?(?:\\[[^\\[\\]]+\\])*(?:\\[\\])?$")
+var rgxQueryPathFull = regexp.MustCompile("^(?:[^\\[\\]]+)?(?:\\[[^\\[\\]]*\\])*$")
 func isPermitted(perm permittable, path string) bool {
 	return rgxQueryPathFull.MatchString(path) &&
 		perm.isPermitted(
@@ -38,9 +45,9 @@ func isPermitted(perm permittable, path string) bool {
 //
 //   • Key (KeyLiteral) defines a whitelisted key:
 //
-//     - string literal of ASCII numbers and letters, eg. "someKey"
+//     - string literal of ASCII numbers, letters and dashes, eg. "someKey" or "X-Tenant-Id"
 //
-//     - single quote (') wrapped string literal of ASCII numbers, letters and spaces, eg. "'some key'"
+//     - single quote (') wrapped string literal of ASCII numbers, letters, dashes and spaces, eg. "'some key'"
 //
 //  
 //
@@ -76,6 +83,19 @@ func isPermitted(perm permittable, path string) bool {
 //     "{ key1:[], key2:{objKey}, key3 }"
 //       matches query
 //     "[0][key1][]=value1&[0][key1][]=value2&[0][key2][objKey]=objValue&[0][key3]=keyValue"
+//
+//  
+//
+//   • Constraint (ConstraintLiteral) defines a whitelisted key of which values are verified by ValidateValues:
+//
+//     - "KeyLiteral:(value1|value2)" permits one of the values, eg. "status:(draft|published)"
+//
+//     - "KeyLiteral:<=N" and "KeyLiteral:>=N" limit the length of the values, eg. "title:<=200"
+//
+//     - "KeyLiteral:len[min..max]" limits the length of the values, eg. "code:len[2..8]"
+//
+//     - "KeyLiteral:int[min..max]" and "KeyLiteral:float[min..max]" permit the numbers in the range, eg.
+//     "age:int[0..150]". Either of the bounds can be omitted and "int" or "float" alone permits any number.
 func ParsePermitted(rules... string) (Permittable, error) {
 	return buildRules(rules...)
 }
@@ -116,18 +136,25 @@ func buildRules(ruleStrings ...string) (permitter Permittable, err error) {
 	}
 }
 
-var rgxValidRuleChars = regexp.MustCompile("[^\\w %,:'{}\\[\\]]")
+var rgxValidRuleChars = regexp.MustCompile("[^\\w %,:'{}\\[\\]-]")
 func buildRule(ruleString string) (_ Permittable, err error) {
 	builder := &permittableBuilder{}
 
-	invalidChars := rgxValidRuleChars.FindAllString(ruleString, -1)
+	// The constraints of the keys are verified when building the constraint elements
+	unconstrainedRuleString := rgxConstraint.ReplaceAllString(ruleString, "${Key}${End}")
+	invalidChars := rgxValidRuleChars.FindAllString(unconstrainedRuleString, -1)
 	if invalidChars != nil {
 		invalidChars = funk.UniqString(invalidChars)
 		return nil, errors.Errorf("rule `%s` contains invalid chars: %v", ruleString, invalidChars)
 	}
 
+	constrainedRuleString, err := builder.processConstraints(ruleString)
+	if err != nil {
+		return nil, err
+	}
+
 	iterRuleString := ""
-	for currRuleString := ruleString ; currRuleString != iterRuleString && currRuleString != "";  {
+	for currRuleString := constrainedRuleString ; currRuleString != iterRuleString && currRuleString != "";  {
 		iterRuleString = currRuleString
 		if currRuleString, err = builder.processObjectGroups(currRuleString); err != nil { return nil, err }
 		if currRuleString, err = builder.processArrayGroups(currRuleString); err != nil { return nil, err }
@@ -40,3 +40,11 @@ func (this *arrayElement) isPermitted(rgxResultTail [][]string) bool {
 func (this *arrayElement) IsPermitted(path string) bool {
 	return isPermitted(this, path)
 }
+
+func (this *arrayElement) MatchCollection(path string) ([]int, bool) {
+	return matchCollection(this, path)
+}
+
+func (this *arrayElement) ValidateValues(path string, values []string) error {
+	return validatePathValues(this, path, values)
+}
@@ -0,0 +1,62 @@
+package permitter
+
+// matchCollection verifies the `path` like isPermitted but additionally matches the hash keys, eg. "abc" of
+// "items[abc][name]", as the elements of the array rules. It returns the indexes of the path segments matched as the
+// array elements.
+func matchCollection(perm permittable, path string) ([]int, bool) {
+	if !rgxQueryPathFull.MatchString(path) {
+		return nil, false
+	}
+	return matchCollectionTail(perm, rgxQueryPathGroup.FindAllStringSubmatch(path, -1), 0)
+}
+
+func matchCollectionTail(perm permittable, rgxResultTail [][]string, position int) ([]int, bool) {
+	switch typedPerm := perm.(type) {
+	case *objElement:
+		if len(rgxResultTail) == 0 {
+			return nil, false
+		}
+		key := permitKeyElement(rgxResultTail[idxGroup][rgxIdxKey] + rgxResultTail[idxGroup][rgxIdxObject])
+		if subElem, ok := (*typedPerm)[key]; ok {
+			return matchCollectionTail(subElem, rgxResultTail[1:], position+1)
+		}
+		return nil, false
+
+	case *arrayElement:
+		if len(rgxResultTail) == 0 {
+			return nil, false
+		}
+		rgxResult := rgxResultTail[idxGroup]
+		isElement := rgxResult[rgxIdxArrIdx1] != "" || rgxResult[rgxIdxArray] != ""
+		if !isElement {
+			return nil, false
+		}
+
+		isLastElement := len(rgxResultTail) == 1
+		if isLastElement && len(*typedPerm) == 0 {
+			return []int{position}, true
+		}
+		if !isLastElement {
+			for _, subElem := range *typedPerm {
+				if positions, ok := matchCollectionTail(subElem, rgxResultTail[1:], position+1); ok {
+					return append([]int{position}, positions...), true
+				}
+			}
+		}
+		return nil, false
+
+	case *mapElement:
+		if len(rgxResultTail) == 0 {
+			return nil, false
+		}
+		rgxResult := rgxResultTail[idxGroup]
+		hasKey := rgxResult[rgxIdxKey] != "" || rgxResult[rgxIdxObject] != "" ||
+			rgxResult[rgxIdxArrIdx1] != "" || rgxResult[rgxIdxArrIdx2] != ""
+		if !hasKey {
+			return nil, false
+		}
+		return matchCollectionTail(typedPerm.value, rgxResultTail[1:], position+1)
+	}
+
+	return nil, perm.isPermitted(rgxResultTail)
+}
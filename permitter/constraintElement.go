@@ -0,0 +1,233 @@
+package permitter
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// constraintElement is a whitelisted key of which values are constrained, eg. "status:(draft|published)".
+type constraintElement struct {
+	rule  string
+	check func(value string) error
+}
+
+func (this *constraintElement) isPermitted(rgxResultTail [][]string) bool {
+	return rgxResultTail != nil && len(rgxResultTail) == 0
+}
+
+func (this *constraintElement) IsPermitted(path string) bool {
+	return isPermitted(this, path)
+}
+
+func (this *constraintElement) MatchCollection(path string) ([]int, bool) {
+	return matchCollection(this, path)
+}
+
+func (this *constraintElement) ValidateValues(path string, values []string) error {
+	return validatePathValues(this, path, values)
+}
+
+var rgxConstraint = regexp.MustCompile("(?P<Key>" + keyPattern + ")\\s*:\\s*(?P<Constraint>" +
+	"\\([^()]*\\)" +
+	"|[<>]=\\s*\\d+" +
+	"|(?:int|float|len)(?:\\[[^\\[\\]]*\\])?" +
+	")\\s*(?P<End>[,}\\]]|$)")
+
+var rgxRange = regexp.MustCompile(
+	"^(?P<Type>int|float|len)(?:\\[\\s*(?P<Min>-?[\\d.]*?)\\s*\\.\\.\\s*(?P<Max>-?[\\d.]*)\\s*\\])?$")
+
+// processConstraints replaces the constraints of the keys of the `ruleString` with the references of the built
+// constraint elements, eg. "age:int[0..150]" with "age:@0@".
+func (this *permittableBuilder) processConstraints(ruleString string) (string, error) {
+	rule := ruleString
+
+	for _, constraintResult := range rgxConstraint.FindAllStringSubmatch(ruleString, -1) {
+		constraint := constraintResult[rgxConstraint.SubexpIndex("Constraint")]
+		check, err := buildConstraintCheck(constraint)
+		if err != nil {
+			return "", err
+		}
+
+		*this = append(*this, &constraintElement{rule: constraint, check: check})
+		idx := len(*this) - 1
+		key := constraintResult[rgxConstraint.SubexpIndex("Key")]
+		end := constraintResult[rgxConstraint.SubexpIndex("End")]
+		rule = strings.Replace(rule, constraintResult[idxGroup], fmt.Sprintf("%s:@%d@%s", key, idx, end), 1)
+	}
+
+	return rule, nil
+}
+
+func buildConstraintCheck(constraint string) (func(value string) error, error) {
+	switch {
+	case strings.HasPrefix(constraint, "("):
+		var options []string
+		for _, option := range strings.Split(constraint[1:len(constraint)-1], "|") {
+			options = append(options, strings.TrimSpace(noQuotes(strings.TrimSpace(option))))
+		}
+		return func(value string) error {
+			for _, option := range options {
+				if value == option {
+					return nil
+				}
+			}
+			return errors.Errorf("must be one of: %s", strings.Join(options, ", "))
+		}, nil
+
+	case strings.HasPrefix(constraint, "<="), strings.HasPrefix(constraint, ">="):
+		length, err := strconv.Atoi(strings.TrimSpace(constraint[2:]))
+		if err != nil {
+			return nil, errors.Errorf("invalid length constraint `%s`", constraint)
+		}
+		if constraint[0] == '<' {
+			return lengthCheck(nil, &length), nil
+		}
+		return lengthCheck(&length, nil), nil
+	}
+
+	rangeResult := rgxRange.FindStringSubmatch(constraint)
+	if rangeResult == nil {
+		return nil, errors.Errorf("invalid constraint `%s`", constraint)
+	}
+	min, err := parseBound(rangeResult[rgxRange.SubexpIndex("Min")])
+	if err != nil {
+		return nil, errors.Errorf("invalid minimum of constraint `%s`", constraint)
+	}
+	max, err := parseBound(rangeResult[rgxRange.SubexpIndex("Max")])
+	if err != nil {
+		return nil, errors.Errorf("invalid maximum of constraint `%s`", constraint)
+	}
+
+	switch rangeResult[rgxRange.SubexpIndex("Type")] {
+	case "int":
+		return func(value string) error {
+			number, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || !inRange(float64(number), min, max) {
+				return errors.New("must be an integer" + describeRange(min, max))
+			}
+			return nil
+		}, nil
+
+	case "float":
+		return func(value string) error {
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil || !inRange(number, min, max) {
+				return errors.New("must be a number" + describeRange(min, max))
+			}
+			return nil
+		}, nil
+	}
+
+	var minLength, maxLength *int
+	if min != nil {
+		length := int(*min)
+		minLength = &length
+	}
+	if max != nil {
+		length := int(*max)
+		maxLength = &length
+	}
+	return lengthCheck(minLength, maxLength), nil
+}
+
+func lengthCheck(min *int, max *int) func(value string) error {
+	return func(value string) error {
+		length := utf8.RuneCountInString(value)
+		switch {
+		case min != nil && max != nil && (length < *min || length > *max):
+			return errors.Errorf("must be between %d and %d characters", *min, *max)
+		case min != nil && length < *min:
+			return errors.Errorf("must be at least %d characters", *min)
+		case max != nil && length > *max:
+			return errors.Errorf("must be at most %d characters", *max)
+		}
+		return nil
+	}
+}
+
+func parseBound(bound string) (*float64, error) {
+	if bound == "" {
+		return nil, nil
+	}
+	value, err := strconv.ParseFloat(bound, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+func inRange(number float64, min *float64, max *float64) bool {
+	return (min == nil || number >= *min) && (max == nil || number <= *max)
+}
+
+func describeRange(min *float64, max *float64) string {
+	format := func(bound float64) string {
+		return strconv.FormatFloat(bound, 'f', -1, 64)
+	}
+	switch {
+	case min != nil && max != nil:
+		return fmt.Sprintf(" between %s and %s", format(*min), format(*max))
+	case min != nil:
+		return fmt.Sprintf(" of at least %s", format(*min))
+	case max != nil:
+		return fmt.Sprintf(" of at most %s", format(*max))
+	}
+	return ""
+}
+
+func validatePathValues(perm permittable, path string, values []string) error {
+	if !rgxQueryPathFull.MatchString(path) {
+		return nil
+	}
+	return validateValues(perm, rgxQueryPathGroup.FindAllStringSubmatch(path, -1), values)
+}
+
+// validateValues verifies the `values` of the permitted path by the constraint element matching the path. The hash
+// keys are matched as the array elements.
+func validateValues(perm permittable, rgxResultTail [][]string, values []string) error {
+	switch typedPerm := perm.(type) {
+	case *constraintElement:
+		if len(rgxResultTail) != 0 {
+			return nil
+		}
+		for _, value := range values {
+			if value == "" {
+				continue
+			}
+			if err := typedPerm.check(value); err != nil {
+				return err
+			}
+		}
+
+	case *objElement:
+		if len(rgxResultTail) == 0 {
+			return nil
+		}
+		key := permitKeyElement(rgxResultTail[idxGroup][rgxIdxKey] + rgxResultTail[idxGroup][rgxIdxObject])
+		if subElem, ok := (*typedPerm)[key]; ok {
+			return validateValues(subElem, rgxResultTail[1:], values)
+		}
+
+	case *arrayElement:
+		if len(rgxResultTail) < 2 {
+			return nil
+		}
+		for _, subElem := range *typedPerm {
+			if _, ok := matchCollectionTail(subElem, rgxResultTail[1:], 0); ok {
+				return validateValues(subElem, rgxResultTail[1:], values)
+			}
+		}
+
+	case *mapElement:
+		if len(rgxResultTail) == 0 {
+			return nil
+		}
+		return validateValues(typedPerm.value, rgxResultTail[1:], values)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package permitter
+
+type mapElement struct {
+	value permittable
+}
+
+func (this *mapElement) isPermitted(rgxResultTail [][]string) bool {
+	if len(rgxResultTail) == 0 {
+		return false
+	}
+
+	rgxResult := rgxResultTail[idxGroup]
+	hasKey := rgxResult[rgxIdxKey] != "" || rgxResult[rgxIdxObject] != "" ||
+		rgxResult[rgxIdxArrIdx1] != "" || rgxResult[rgxIdxArrIdx2] != ""
+	if !hasKey {
+		return false
+	}
+
+	return this.value.isPermitted(rgxResultTail[1:])
+}
+
+func (this *mapElement) IsPermitted(path string) bool {
+	return isPermitted(this, path)
+}
+
+func (this *mapElement) MatchCollection(path string) ([]int, bool) {
+	return matchCollection(this, path)
+}
+
+func (this *mapElement) ValidateValues(path string, values []string) error {
+	return validatePathValues(this, path, values)
+}
@@ -40,3 +40,10 @@ func (this *objElement) IsPermitted(path string) bool {
 	return isPermitted(this, path)
 }
 
+func (this *objElement) MatchCollection(path string) ([]int, bool) {
+	return matchCollection(this, path)
+}
+
+func (this *objElement) ValidateValues(path string, values []string) error {
+	return validatePathValues(this, path, values)
+}
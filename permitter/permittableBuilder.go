@@ -12,13 +12,13 @@ type permittableBuilder []Permittable
 
 var permitterIdPattern = "@(?P<PermitterID>\\d+)@"
 var rgxPermitterIdOnly = regexp.MustCompile("^"+permitterIdPattern+"$")
-var keyPattern = "'[%\\w ]+'|[%\\w]+"
+var keyPattern = "'[%\\w -]+'|[%\\w-]+"
 var keysPattern = "^\\s*(?:"+permitterIdPattern+"|(?P<Key>"+keyPattern+"))\\s*(?:,|$)"
 var rgxKeys = regexp.MustCompile(keysPattern)
 var rgxKeysInObj = regexp.MustCompile("" +
-	"\\{(?P<Object>[@%'\\w ,:]+)\\}" +
+	"\\{(?P<Object>[@%'\\w ,:-]+)\\}" +
 	"|" +
-	"^(?P<Keys>[@%'\\w ,\\:]+)$")
+	"^(?P<Keys>[@%'\\w ,\\:-]+)$")
 var rgxMerge = regexp.MustCompile("(?P<Key>"+keyPattern+")"+"\\s*:\\s*"+permitterIdPattern+"\\s*(?:,|$)")
 func (this *permittableBuilder) processObjectGroups(ruleString string) (string, error) {
 	rule := ruleString
@@ -80,13 +80,13 @@ func (this *permittableBuilder) processObjectGroups(ruleString string) (string,
 
 		*this = append(*this, &obj)
 		idx := len(*this)-1
-		rule = strings.ReplaceAll(rule, keysResult[idxGroup], fmt.Sprintf("@%d@", idx))
+		rule = strings.Replace(rule, keysResult[idxGroup], fmt.Sprintf("@%d@", idx), 1)
 	}
 
 	return rule, nil
 }
 
-var rgxKeysInArr = regexp.MustCompile("\\[(?P<Array>[@%'\\w ,:]*)\\]")
+var rgxKeysInArr = regexp.MustCompile("\\[(?P<Array>[@%'\\w ,:-]*)\\]")
 func (this *permittableBuilder) processArrayGroups(ruleString string) (transformedRule string, err error) {
 	rule := ruleString
 
@@ -95,10 +95,12 @@ func (this *permittableBuilder) processArrayGroups(ruleString string) (transform
 		arr := arrayElement{}
 
 		for _, mergeResult := range rgxMerge.FindAllStringSubmatch(arrayData, -1) {
-			if mergedData, err := this.processObjectGroups(mergeResult[0]); err != nil {
+			// The separating comma is kept to separate the merged key from the following keys
+			merge := strings.TrimSuffix(strings.TrimSpace(mergeResult[0]), ",")
+			if mergedData, err := this.processObjectGroups(merge); err != nil {
 				return "", err
 			} else {
-				arrayData = strings.Replace(arrayData, mergeResult[0], mergedData, 1)
+				arrayData = strings.Replace(arrayData, merge, mergedData, 1)
 			}
 		}
 
@@ -136,7 +138,7 @@ func (this *permittableBuilder) processArrayGroups(ruleString string) (transform
 
 		*this = append(*this, &arr)
 		idx := len(*this)-1
-		rule = strings.ReplaceAll(rule, arrayResult[idxGroup], fmt.Sprintf("@%d@", idx))
+		rule = strings.Replace(rule, arrayResult[idxGroup], fmt.Sprintf("@%d@", idx), 1)
 	}
 
 	return rule, nil
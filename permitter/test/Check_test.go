@@ -0,0 +1,39 @@
+package permittertest
+
+import (
+	"github.com/stretchr/testify/assert"
+	. "github.com/vellotis/go-strongparams/permitter"
+	"reflect"
+	"testing"
+)
+
+func Test_Check_NestedArrays(t *testing.T) {
+	type Line struct {
+		Sku string `params:"sku"`
+	}
+	type Order struct {
+		Lines []Line `params:"lines,required"`
+	}
+	type Root struct {
+		Orders []Order `params:"orders"`
+	}
+
+	mismatches := Check(MustParsePermitted("orders:[lines:[sku, qty]]"), reflect.TypeOf(Root{}))
+
+	assert.Equal(t, []Mismatch{
+		{Kind: UnknownField, Path: "orders[][lines][][qty]", Message: "no matching field in `permittertest.Line`"},
+	}, mismatches)
+}
+
+func Test_CheckWithAliasTag_RequiredNotPermitted(t *testing.T) {
+	type KeyValue struct {
+		Key   string `custom:"key,required"`
+		Value string `custom:"value"`
+	}
+
+	mismatches := CheckWithAliasTag(MustParsePermitted("value"), reflect.TypeOf(KeyValue{}), "custom")
+
+	assert.Equal(t, []Mismatch{
+		{Kind: RequiredNotPermitted, Path: "key", Message: "required field `permittertest.KeyValue.Key` is not permitted"},
+	}, mismatches)
+}
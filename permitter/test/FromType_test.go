@@ -0,0 +1,93 @@
+package permittertest
+
+import (
+	"github.com/stretchr/testify/assert"
+	. "github.com/vellotis/go-strongparams/permitter"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type fromTypeAddress struct {
+	Street string `params:"street"`
+}
+
+type fromTypeBase struct {
+	ID int `params:"id"`
+}
+
+type fromTypeUser struct {
+	fromTypeBase
+	Name      string            `params:"name"`
+	Born      *time.Time        `params:"born"`
+	Tags      []string          `params:"tags"`
+	Address   *fromTypeAddress  `params:"address"`
+	Addresses []fromTypeAddress `params:"addresses"`
+	Meta      map[string]string `params:"meta"`
+	Admin     bool              `params:"admin" permit:"admin"`
+	Password  string            `params:"password" permit:"-"`
+	Ignored   string            `params:"-"`
+	NoTag     string
+	internal  string
+}
+
+func Test_FromType(t *testing.T) {
+	permittable, err := FromType(reflect.TypeOf(&fromTypeUser{}))
+
+	if assert.NoError(t, err) &&
+		assert.True(t, permittable.IsPermitted("id")) &&
+		assert.True(t, permittable.IsPermitted("name")) &&
+		assert.True(t, permittable.IsPermitted("born")) &&
+		assert.True(t, permittable.IsPermitted("tags[]")) &&
+		assert.True(t, permittable.IsPermitted("tags[0]")) &&
+		assert.True(t, permittable.IsPermitted("address[street]")) &&
+		assert.True(t, permittable.IsPermitted("addresses[0][street]")) &&
+		assert.True(t, permittable.IsPermitted("meta[anyKey]")) &&
+		assert.True(t, permittable.IsPermitted("NoTag")) &&
+		assert.False(t, permittable.IsPermitted("address")) &&
+		assert.False(t, permittable.IsPermitted("address[notPresent]")) &&
+		assert.False(t, permittable.IsPermitted("meta")) &&
+		assert.False(t, permittable.IsPermitted("admin")) &&
+		assert.False(t, permittable.IsPermitted("password")) &&
+		assert.False(t, permittable.IsPermitted("Ignored")) &&
+		assert.False(t, permittable.IsPermitted("internal")) {
+	}
+}
+
+func Test_FromType_WithRole(t *testing.T) {
+	permittable, err := FromType(reflect.TypeOf(fromTypeUser{}), "admin")
+
+	if assert.NoError(t, err) &&
+		assert.True(t, permittable.IsPermitted("admin")) &&
+		assert.False(t, permittable.IsPermitted("password")) {
+	}
+}
+
+func Test_FromTypeWithAliasTag(t *testing.T) {
+	type KeyValue struct {
+		Key string `custom:"customKey"`
+	}
+
+	permittable, err := FromTypeWithAliasTag(reflect.TypeOf(KeyValue{}), "custom")
+
+	if assert.NoError(t, err) &&
+		assert.True(t, permittable.IsPermitted("customKey")) &&
+		assert.False(t, permittable.IsPermitted("Key")) {
+	}
+}
+
+func Test_FromType_RecursiveType(t *testing.T) {
+	type Node struct {
+		Children []*Node `params:"children"`
+	}
+
+	_, err := FromType(reflect.TypeOf(Node{}))
+
+	assert.Error(t, err)
+}
+
+func Test_FromType_NotStruct(t *testing.T) {
+	_, err := FromType(reflect.TypeOf(""))
+
+	assert.EqualError(t, err, "type `string` is not a struct")
+}
@@ -289,3 +289,115 @@ func Test_ParsePermitted_And_IsPermitted_ComplexStructureWithSpacesAndOnlyQuoted
 		assert.False(t, permittable.IsPermitted("key[nested2 x 1][nested2 x 2][0][nested2 x 4][notPresent]")) {
 	}
 }
+
+func Test_ParsePermitted_And_IsPermitted_DashedKeys(t *testing.T) {
+	permitted, err := ParsePermitted("X-Api-Version, 'X-Tenant Id', obj:{sub-key}, arr:[item-key]")
+
+	if assert.NoError(t, err) &&
+		assert.True(t, permitted.IsPermitted("X-Api-Version")) &&
+		assert.True(t, permitted.IsPermitted("X-Tenant Id")) &&
+		assert.True(t, permitted.IsPermitted("obj[sub-key]")) &&
+		assert.True(t, permitted.IsPermitted("arr[0][item-key]")) {
+		assert.False(t, permitted.IsPermitted("X-Api"))
+	}
+}
+
+func Test_ParsePermitted_And_IsPermitted_EmptyBracketArrayOfObjects(t *testing.T) {
+	permitted, err := ParsePermitted("items:[name, tags:[]]")
+	permittedNested, errNested := ParsePermitted("items:[{sub:{key}}]")
+
+	if assert.NoError(t, err) &&
+		assert.NoError(t, errNested) &&
+		assert.True(t, permitted.IsPermitted("items[][name]")) &&
+		assert.True(t, permitted.IsPermitted("items[][tags][]")) &&
+		assert.True(t, permittedNested.IsPermitted("items[][sub][key]")) {
+		assert.False(t, permitted.IsPermitted("items[][admin]"))
+		assert.False(t, permittedNested.IsPermitted("items[][sub][]"))
+	}
+}
+
+func Test_ParsePermitted_And_MatchCollection(t *testing.T) {
+	permitted, err := ParsePermitted("items:[name, tags:[]], meta:{key}")
+
+	if assert.NoError(t, err) {
+		positions, ok := permitted.MatchCollection("items[abc][name]")
+		assert.True(t, ok)
+		assert.Equal(t, []int{1}, positions)
+
+		positions, ok = permitted.MatchCollection("items[0][tags][new]")
+		assert.True(t, ok)
+		assert.Equal(t, []int{1, 3}, positions)
+
+		positions, ok = permitted.MatchCollection("meta[key]")
+		assert.True(t, ok)
+		assert.Empty(t, positions)
+
+		_, ok = permitted.MatchCollection("items[abc][admin]")
+		assert.False(t, ok)
+		assert.False(t, permitted.IsPermitted("items[abc][name]"))
+	}
+}
+
+func Test_ParsePermitted_And_IsPermitted_SiblingArrays(t *testing.T) {
+	permitted, err := ParsePermitted("ids:[], tags:[], items:[name, sub:{key}]")
+
+	if assert.NoError(t, err) &&
+		assert.True(t, permitted.IsPermitted("ids[]")) &&
+		assert.True(t, permitted.IsPermitted("tags[]")) &&
+		assert.True(t, permitted.IsPermitted("items[0][name]")) &&
+		assert.True(t, permitted.IsPermitted("items[0][sub][key]")) {
+		assert.False(t, permitted.IsPermitted("ids[0][name]"))
+	}
+}
+
+func Test_ParsePermitted_And_IsPermitted_MergedKeysInArray(t *testing.T) {
+	permitted, err := ParsePermitted("items:[a:{x}, b:{y}, c]")
+
+	if assert.NoError(t, err) &&
+		assert.True(t, permitted.IsPermitted("items[0][a][x]")) &&
+		assert.True(t, permitted.IsPermitted("items[0][b][y]")) &&
+		assert.True(t, permitted.IsPermitted("items[0][c]")) {
+		assert.False(t, permitted.IsPermitted("items[0][a][y]"))
+	}
+}
+
+func Test_ParsePermitted_And_ValidateValues(t *testing.T) {
+	permitted, err := ParsePermitted("status:(draft|published), title:<=5, code:>=2, slug:len[2..3], age:int[0..150], " +
+		"count:int, price:float[0.5..], items:[name:<=3, tags:[]], meta:{kind:('a b'|c)}")
+
+	if assert.NoError(t, err) &&
+		assert.True(t, permitted.IsPermitted("status")) &&
+		assert.True(t, permitted.IsPermitted("items[0][name]")) &&
+		assert.True(t, permitted.IsPermitted("items[0][tags][]")) &&
+		assert.True(t, permitted.IsPermitted("meta[kind]")) &&
+		assert.False(t, permitted.IsPermitted("status[x]")) &&
+		assert.NoError(t, permitted.ValidateValues("status", []string{"draft", "published"})) &&
+		assert.NoError(t, permitted.ValidateValues("title", []string{"abcde", ""})) &&
+		assert.NoError(t, permitted.ValidateValues("age", []string{"0", "150"})) &&
+		assert.NoError(t, permitted.ValidateValues("price", []string{"0.5", "1e3"})) &&
+		assert.NoError(t, permitted.ValidateValues("items[0][tags][]", []string{"anything"})) &&
+		assert.NoError(t, permitted.ValidateValues("items[abc][name]", []string{"abc"})) &&
+		assert.NoError(t, permitted.ValidateValues("meta[kind]", []string{"a b"})) &&
+		assert.EqualError(t, permitted.ValidateValues("status", []string{"draft", "deleted"}), "must be one of: draft, published") &&
+		assert.EqualError(t, permitted.ValidateValues("title", []string{"abcdef"}), "must be at most 5 characters") &&
+		assert.EqualError(t, permitted.ValidateValues("code", []string{"a"}), "must be at least 2 characters") &&
+		assert.EqualError(t, permitted.ValidateValues("slug", []string{"abcd"}), "must be between 2 and 3 characters") &&
+		assert.EqualError(t, permitted.ValidateValues("age", []string{"151"}), "must be an integer between 0 and 150") &&
+		assert.EqualError(t, permitted.ValidateValues("count", []string{"1.5"}), "must be an integer") &&
+		assert.EqualError(t, permitted.ValidateValues("price", []string{"0.4"}), "must be a number of at least 0.5") &&
+		assert.EqualError(t, permitted.ValidateValues("items[0][name]", []string{"abcd"}), "must be at most 3 characters") &&
+		assert.EqualError(t, permitted.ValidateValues("items[abc][name]", []string{"abcd"}), "must be at most 3 characters") {
+		assert.EqualError(t, permitted.ValidateValues("meta[kind]", []string{"a"}), "must be one of: a b, c")
+	}
+}
+
+func Test_ParsePermitted_InvalidConstraints(t *testing.T) {
+	_, errRange := ParsePermitted("age:int[a..b]")
+	_, errChars := ParsePermitted("age:int<5")
+
+	if assert.Error(t, errRange) &&
+		assert.Contains(t, errRange.Error(), "invalid constraint `int[a..b]`") &&
+		assert.Error(t, errChars) {
+		assert.Contains(t, errChars.Error(), "contains invalid chars")
+	}
+}
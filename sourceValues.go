@@ -0,0 +1,162 @@
+package strongparams
+
+import (
+	"encoding/json"
+	"mime/multipart"
+	"net/url"
+	"strconv"
+)
+
+// sourceValues holds the url.Values retrieved from a parameters source. Sources which preserve the value types, eg.
+// JSON, additionally hold the typed values by the same keys. Sources of files, ie. Multipart, hold the files by their
+// keys which are also registered in the url.Values without any values. Merged sources hold the names of the sources
+// the keys came from. Sources which struct fields are resolved from a dedicated tag, ie. Headers and Cookies, hold the
+// tag. The permitted sources hold the resolver of the collection element positions of the keys. The required sources
+// hold the transformers of the paths to and from the keys relative to the required key. The error of retrieving the
+// values is deferred until the ReturnTarget or ReturnOfType function is executed.
+type sourceValues struct {
+	values url.Values
+	typed  map[string][]interface{}
+	files  map[string][]*multipart.FileHeader
+	// fileForms holds the forms of the files which own the temporary files of the files
+	fileForms map[*multipart.FileHeader]*multipart.Form
+	origins   map[string]string
+	aliasTag  string
+	error     error
+
+	collectionPositions func(key string) []int
+	transformPath       func(path string) (string, bool)
+	untransformKey      func(path string) string
+}
+
+func newSourceValues(values url.Values) *sourceValues {
+	return &sourceValues{
+		values: cloneUrlValues(values),
+	}
+}
+
+func newSourceError(err error) *sourceValues {
+	return &sourceValues{
+		values: url.Values{},
+		error:  err,
+	}
+}
+
+// transformed returns new sourceValues of the transformed `values` holding the typed values and the files of the
+// receiver and the origins of the keys with the keys transformed by `transformKey`. The keys for which `transformKey`
+// returns false are dropped.
+func (this *sourceValues) transformed(values url.Values, transformKey func(key string) (string, bool)) *sourceValues {
+	transformed := &sourceValues{
+		values:    values,
+		aliasTag:  this.aliasTag,
+		fileForms: this.fileForms,
+	}
+
+	if this.typed != nil {
+		transformed.typed = make(map[string][]interface{}, len(this.typed))
+		for key, value := range this.typed {
+			if transformedKey, ok := transformKey(key); ok {
+				transformed.typed[transformedKey] = value
+			}
+		}
+	}
+
+	if this.files != nil {
+		transformed.files = make(map[string][]*multipart.FileHeader, len(this.files))
+		for key, value := range this.files {
+			if transformedKey, ok := transformKey(key); ok {
+				transformed.files[transformedKey] = value
+			}
+		}
+	}
+
+	if this.origins != nil {
+		transformed.origins = make(map[string]string, len(this.origins))
+		for key, value := range this.origins {
+			if transformedKey, ok := transformKey(key); ok {
+				transformed.origins[transformedKey] = value
+			}
+		}
+	}
+
+	return transformed
+}
+
+// typedValues returns the typed values of the `key` key if they are consistent with the `values` string values.
+// Otherwise the string values are returned.
+func typedValues(typed map[string][]interface{}, key string, values []string) []interface{} {
+	if typedValue, ok := typed[key]; ok && isTypedConsistent(typedValue, values) {
+		return typedValue
+	}
+
+	result := make([]interface{}, len(values))
+	for idx, value := range values {
+		result[idx] = value
+	}
+	return result
+}
+
+func isTypedConsistent(typed []interface{}, values []string) bool {
+	idx := 0
+	for _, typedValue := range typed {
+		if typedValue == nil {
+			continue
+		}
+		if idx >= len(values) || stringifyTyped(typedValue) != values[idx] {
+			return false
+		}
+		idx++
+	}
+	return idx == len(values)
+}
+
+func stringifyTyped(value interface{}) string {
+	switch typedValue := value.(type) {
+	case string:
+		return typedValue
+	case json.Number:
+		return typedValue.String()
+	case bool:
+		return strconv.FormatBool(typedValue)
+	}
+	return ""
+}
+
+// renamed returns the sourceValues with the keys renamed by the `rename` map. The values of the keys renamed to the
+// same key are merged.
+func (this *sourceValues) renamed(rename map[string]string) *sourceValues {
+	if len(rename) == 0 {
+		return this
+	}
+	renamedKey := func(key string) string {
+		if newKey, ok := rename[key]; ok {
+			return newKey
+		}
+		return key
+	}
+
+	renamed := *this
+	renamed.values = make(url.Values, len(this.values))
+	for key, value := range this.values {
+		renamed.values[renamedKey(key)] = append(renamed.values[renamedKey(key)], value...)
+	}
+	if this.typed != nil {
+		renamed.typed = make(map[string][]interface{}, len(this.typed))
+		for key, value := range this.typed {
+			renamed.typed[renamedKey(key)] = append(renamed.typed[renamedKey(key)], value...)
+		}
+	}
+	if this.files != nil {
+		renamed.files = make(map[string][]*multipart.FileHeader, len(this.files))
+		for key, value := range this.files {
+			renamed.files[renamedKey(key)] = append(renamed.files[renamedKey(key)], value...)
+		}
+	}
+	if this.origins != nil {
+		renamed.origins = make(map[string]string, len(this.origins))
+		for key, value := range this.origins {
+			renamed.origins[renamedKey(key)] = value
+		}
+	}
+	return &renamed
+}
@@ -0,0 +1,88 @@
+package strongparamstest
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	. "github.com/vellotis/go-strongparams"
+	"testing"
+)
+
+type arrayFormatEntity struct {
+	IDs  []int    `params:"ids"`
+	Tags []string `params:"tags"`
+}
+
+func Test_Permit_ArrayFormat_Multi(t *testing.T) {
+	queries := []string{
+		"ids=1&ids=2",
+		"ids[]=1&ids[]=2",
+		"ids[1]=2&ids[0]=1",
+		"ids=1&ids[]=2",
+	}
+
+	for _, query := range queries {
+		result := arrayFormatEntity{}
+
+		err := Params().Permit("ids:[]").Values(mockQueryValues(query))(&result)
+
+		if assert.NoError(t, err, query) &&
+			assert.Equal(t, arrayFormatEntity{IDs: []int{1, 2}}, result, query) {
+		}
+	}
+}
+
+func Test_Permit_WithArrayFormat(t *testing.T) {
+	formats := map[ArrayFormat]string{
+		ArrayComma: "ids=1,2&tags=a,b",
+		ArrayPipe:  "ids=1|2&tags=a|b",
+		ArraySpace: "ids=1%%202&tags=a+b",
+	}
+
+	for format, query := range formats {
+		result := arrayFormatEntity{}
+
+		err := Params().WithArrayFormat(format).Permit("ids:[], tags:[]").Values(mockQueryValues(query))(&result)
+
+		if assert.NoError(t, err, query) &&
+			assert.Equal(t, arrayFormatEntity{IDs: []int{1, 2}, Tags: []string{"a", "b"}}, result, query) {
+		}
+	}
+}
+
+func Test_Require_Permit_WithArrayFormat_PerField(t *testing.T) {
+	values := mockQueryValues("entity[ids]=1,2&entity[tags]=a,b&entity[tags]=c")
+	result := arrayFormatEntity{}
+
+	err := Params().WithArrayFormat(ArrayComma, "entity[ids]").Require("entity").Permit("ids:[], tags:[]").
+		Values(values)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, arrayFormatEntity{IDs: []int{1, 2}, Tags: []string{"a,b", "c"}}, result) {
+	}
+}
+
+func Test_Params_WithArrayFormat_PerFieldWithoutRules(t *testing.T) {
+	values := mockQueryValues("ids=1|2&tags=a|b")
+	result := Tree{}
+
+	err := Params().WithArrayFormat(ArrayPipe, "ids").Values(values)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, Tree{"ids": []interface{}{"1", "2"}, "tags": "a|b"}, result) {
+	}
+}
+
+func Test_Permit_WithArrayFormat_MaxArrayLen(t *testing.T) {
+	params := Params().WithArrayFormat(ArrayComma).WithLimits(Limits{MaxArrayLen: 3})
+
+	errWithin := params.Permit("ids:[]").Values(mockQueryValues("ids=1,2,3"))(&arrayFormatEntity{})
+	errExceeded := params.Permit("ids:[]").Values(mockQueryValues("ids=1,2,3,4"))(&arrayFormatEntity{})
+	errMerged := params.Permit("ids:[]").Values(mockQueryValues("ids=1,2&ids[]=3,4"))(&arrayFormatEntity{})
+
+	var limitErr *LimitError
+	if assert.NoError(t, errWithin) &&
+		assert.True(t, errors.As(errExceeded, &limitErr)) &&
+		assert.Equal(t, LimitError{Limit: "MaxArrayLen", Key: "ids", Max: 3}, *limitErr) &&
+		assert.EqualError(t, errMerged, errExceeded.Error()) {
+	}
+}
@@ -0,0 +1,99 @@
+package strongparamstest
+
+import (
+	"github.com/stretchr/testify/assert"
+	. "github.com/vellotis/go-strongparams"
+	"testing"
+)
+
+type blanksUser struct {
+	Name       string   `params:"name"`
+	MiddleName *string  `params:"middle_name"`
+	Nickname   string   `params:"nickname"`
+	Age        *int     `params:"age"`
+	Tags       []string `params:"tags"`
+}
+
+func Test_Permit_WithoutBlankHandling(t *testing.T) {
+	values := mockQueryValues("name=John&middle_name=&tags[]=")
+	result := blanksUser{}
+	resultTree := Tree{}
+
+	err := Params().Permit("name, middle_name, tags:[]").Values(values)(&result)
+	errTree := Params().Permit("name, middle_name, tags:[]").Values(values)(&resultTree)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, "", *result.MiddleName) &&
+		assert.NoError(t, errTree) &&
+		assert.Equal(t, Tree{"name": "John", "middle_name": "", "tags": []interface{}{""}}, resultTree) {
+	}
+}
+
+func Test_Permit_WithBlankHandling_CompactBlank(t *testing.T) {
+	values := mockQueryValues("name=John&nickname=%%20&middle_name=&tags[]=&tags[]=a&tags[]=%%20")
+	result := blanksUser{Nickname: "Johnny"}
+
+	err := Params().WithBlankHandling(CompactBlank).Permit("name, nickname, middle_name, tags:[]").Values(values)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, blanksUser{Name: "John", Nickname: "Johnny", Tags: []string{"a"}}, result) {
+	}
+}
+
+func Test_Permit_WithBlankHandling_EmptyAsNil(t *testing.T) {
+	values := mockQueryValues("name=&nickname=&middle_name=&age=")
+	result := blanksUser{}
+
+	err := Params().WithBlankHandling(EmptyAsNil).Permit("name, nickname, middle_name, age").Values(values)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Nil(t, result.MiddleName) &&
+		assert.Nil(t, result.Age) &&
+		assert.Equal(t, "", result.Name) {
+	}
+}
+
+func Test_Permit_WithBlankHandling_StripArrayPlaceholder(t *testing.T) {
+	values := mockQueryValues("user[name]=&user[tags][]=&user[tags][]=a")
+	result := blanksUser{}
+
+	err := Params().WithBlankHandling(StripArrayPlaceholder).Require("user").Permit("name, tags:[]").Values(values)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, []string{"a"}, result.Tags) {
+	}
+}
+
+func Test_Permit_WithBlankHandling_Tree(t *testing.T) {
+	values := mockQueryValues("name=John&middle_name=&nickname=%%20&tags[]=&ids[]=")
+	result := Tree{}
+
+	err := Params().WithBlankHandling(EmptyAsNil | StripArrayPlaceholder).Permit("name, middle_name, nickname, tags:[], ids:[]").Values(values)(&result)
+	resultCompact := Tree{}
+	errCompact := Params().WithBlankHandling(CompactBlank).Permit("name, middle_name, nickname, tags:[]").Values(values)(&resultCompact)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, Tree{
+			"name":        "John",
+			"middle_name": nil,
+			"nickname":    " ",
+			"tags":        []interface{}{},
+			"ids":         []interface{}{},
+		}, result) &&
+		assert.NoError(t, errCompact) &&
+		assert.Equal(t, Tree{"name": "John"}, resultCompact) {
+	}
+}
+
+func Test_Permit_WithBlankHandling_JSON(t *testing.T) {
+	request := mockRequestWithBody("POST", "/", "application/json", `{"name":"John","middle_name":null,"nickname":"","age":0}`)
+	result := Tree{}
+
+	err := Params().WithBlankHandling(CompactBlank).Permit("name, middle_name, nickname, age").JSON(request)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Len(t, result, 2) &&
+		assert.Equal(t, "John", result["name"]) &&
+		assert.EqualValues(t, "0", result["age"]) {
+	}
+}
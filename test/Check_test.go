@@ -0,0 +1,62 @@
+package strongparamstest
+
+import (
+	"github.com/stretchr/testify/assert"
+	. "github.com/vellotis/go-strongparams"
+	"github.com/vellotis/go-strongparams/permitter"
+	"testing"
+)
+
+type checkAddress struct {
+	Street string `params:"street"`
+}
+
+type checkUser struct {
+	Name    string         `params:"name,required"`
+	Email   string         `params:"email,required"`
+	Tags    []string       `params:"tags"`
+	Address checkAddress   `params:"address"`
+	Items   []checkAddress `params:"items"`
+}
+
+func Test_Check(t *testing.T) {
+	err := Check("name, email, tags:[], address:{street}, items:[street]", &checkUser{})
+
+	assert.NoError(t, err)
+}
+
+func Test_Check_Defaults(t *testing.T) {
+	err := Check("name, email=john@example.com, tags:[]", &checkUser{})
+	errMismatch := Check("name, email, emial=john@example.com", &checkUser{})
+
+	if assert.NoError(t, err) &&
+		assert.IsType(t, &CheckError{}, errMismatch) &&
+		assert.Equal(t, []permitter.Mismatch{
+			{Kind: permitter.UnknownField, Path: "emial", Message: "no matching field in `strongparamstest.checkUser`"},
+		}, errMismatch.(*CheckError).Mismatches) {
+	}
+}
+
+func Test_Check_Mismatches(t *testing.T) {
+	err := Check("name, emial, tags:{key}, address:[], items, address2", &checkUser{})
+
+	if assert.IsType(t, &CheckError{}, err) {
+		mismatches := err.(*CheckError).Mismatches
+		if assert.Equal(t, []permitter.Mismatch{
+			{Kind: permitter.ShapeMismatch, Path: "address", Message: "rule declares an array but the field is `strongparamstest.checkAddress`"},
+			{Kind: permitter.UnknownField, Path: "address2", Message: "no matching field in `strongparamstest.checkUser`"},
+			{Kind: permitter.UnknownField, Path: "emial", Message: "no matching field in `strongparamstest.checkUser`"},
+			{Kind: permitter.ShapeMismatch, Path: "items", Message: "rule declares a value but the field is `[]strongparamstest.checkAddress`"},
+			{Kind: permitter.ShapeMismatch, Path: "tags", Message: "rule declares an object but the field is `[]string`"},
+			{Kind: permitter.RequiredNotPermitted, Path: "email", Message: "required field `strongparamstest.checkUser.Email` is not permitted"},
+		}, mismatches) &&
+			assert.Contains(t, err.Error(), "permit rules are inconsistent with `strongparamstest.checkUser`: `address`: ") {
+		}
+	}
+}
+
+func Test_MustCheck_Panics(t *testing.T) {
+	assert.Panics(t, func() {
+		MustCheck("unknown", &checkUser{})
+	})
+}
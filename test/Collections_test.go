@@ -0,0 +1,58 @@
+package strongparamstest
+
+import (
+	"github.com/stretchr/testify/assert"
+	. "github.com/vellotis/go-strongparams"
+	"testing"
+)
+
+type collectionItem struct {
+	Name string `params:"name"`
+}
+
+type collectionOrder struct {
+	Items []collectionItem `params:"items"`
+	IDs   []int            `params:"ids"`
+}
+
+func Test_Permit_WithCollectionNormalization_HashKeyed(t *testing.T) {
+	values := mockQueryValues("order[items][def456][name]=y&order[items][abc123][name]=x&order[items][abc123][admin]=true")
+	result := collectionOrder{}
+
+	err := Params().WithCollectionNormalization().Require("order").Permit("items:[name]").Values(values)(&result)
+	errDefault := Params().Require("order").Permit("items:[name]").Values(values)(&collectionOrder{})
+
+	if assert.NoError(t, err) &&
+		assert.NoError(t, errDefault) &&
+		assert.Equal(t, collectionOrder{Items: []collectionItem{{Name: "x"}, {Name: "y"}}}, result) {
+	}
+}
+
+func Test_Params_WithCollectionNormalization_Sparse(t *testing.T) {
+	values := mockQueryValues("items[99999][name]=c&items[3][name]=b&items[0][name]=a&ids[10]=2&ids[7]=1")
+	result := collectionOrder{}
+
+	err := Params().WithCollectionNormalization().Values(values)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, collectionOrder{
+			Items: []collectionItem{{Name: "a"}, {Name: "b"}, {Name: "c"}},
+			IDs:   []int{1, 2},
+		}, result) {
+	}
+}
+
+func Test_Permit_WithCollectionNormalization_Mixed_Tree(t *testing.T) {
+	values := mockQueryValues("items[5][name]=b&items[new][name]=c&items[1][name]=a")
+	result := Tree{}
+
+	err := Params().WithCollectionNormalization().Permit("items:[name]").Values(values)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, Tree{"items": []interface{}{
+			Tree{"name": "a"},
+			Tree{"name": "b"},
+			Tree{"name": "c"},
+		}}, result) {
+	}
+}
@@ -0,0 +1,62 @@
+package strongparamstest
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	. "github.com/vellotis/go-strongparams"
+	"testing"
+)
+
+type constrainedPost struct {
+	Status string `params:"status"`
+	Title  string `params:"title"`
+	Age    int    `params:"age"`
+	Items  []struct {
+		Name string `params:"name"`
+	} `params:"items"`
+}
+
+func Test_Require_Permit_ValueConstraints(t *testing.T) {
+	values := mockQueryValues("post[status]=draft&post[title]=Hello&post[age]=42&post[items][0][name]=abc")
+	result := constrainedPost{}
+
+	err := Params().Require("post").Permit("status:(draft|published), title:<=5, age:int[0..150], items:[name:<=3]").
+		Values(values)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, "draft", result.Status) &&
+		assert.Equal(t, 42, result.Age) &&
+		assert.Len(t, result.Items, 1) {
+	}
+}
+
+func Test_Require_Permit_ValueConstraints_ValidationErrors(t *testing.T) {
+	values := mockQueryValues("post[status]=deleted&post[title]=Hello+World&post[age]=200&post[items][0][name]=abcd&post[other]=x")
+
+	err := Params().Require("post").Permit("status:(draft|published), title:<=5, age:int[0..150], items:[name:<=3]").
+		Values(values)(&constrainedPost{})
+
+	var validationErrors ValidationErrors
+	var validationError *ValidationError
+	if assert.True(t, errors.As(err, &validationErrors)) &&
+		assert.Equal(t, ValidationErrors{
+			{Path: "post[age]", Message: "must be an integer between 0 and 150"},
+			{Path: "post[items][0][name]", Message: "must be at most 3 characters"},
+			{Path: "post[status]", Message: "must be one of: draft, published"},
+			{Path: "post[title]", Message: "must be at most 5 characters"},
+		}, validationErrors) &&
+		assert.True(t, errors.As(err, &validationError)) &&
+		assert.EqualError(t, validationError, "`post[age]` must be an integer between 0 and 150") {
+	}
+}
+
+func Test_Permit_ValueConstraints_Sanitized(t *testing.T) {
+	values := mockQueryValues("status=%%20Draft%%20&age=")
+	result := constrainedPost{}
+
+	err := Params().WithSanitizers("status: trim,lower").Permit("status:(draft|published), age:int").Values(values)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, "draft", result.Status) {
+	}
+}
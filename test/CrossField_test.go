@@ -0,0 +1,108 @@
+package strongparamstest
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	. "github.com/vellotis/go-strongparams"
+	"testing"
+)
+
+type searchParams struct {
+	Email     string `params:"email"`
+	Phone     string `params:"phone"`
+	StartDate string `params:"start_date"`
+	EndDate   string `params:"end_date"`
+	Address   struct {
+		Zip string `params:"zip"`
+	} `params:"address"`
+}
+
+func Test_Permit_CrossFieldRules_DSL(t *testing.T) {
+	result := searchParams{}
+
+	err := Params().Require("search").
+		Permit("email, phone, start_date, end_date, oneOf(email, phone), dependsOn(end_date, start_date)").
+		Values(mockQueryValues("search[email]=a@b.c&search[start_date]=2024-01-01&search[end_date]=2024-02-01"))(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, "a@b.c", result.Email) &&
+		assert.Equal(t, "2024-02-01", result.EndDate) {
+	}
+}
+
+func Test_Permit_CrossFieldRules_DSL_Errors(t *testing.T) {
+	err := Params().Require("search").
+		Permit("email, phone, start_date, end_date", "oneOf(email, phone)", "dependsOn(end_date, start_date)").
+		Values(mockQueryValues("search[email]=a@b.c&search[phone]=123&search[end_date]=2024-02-01"))(&searchParams{})
+
+	var crossFieldErrors CrossFieldErrors
+	var crossFieldError *CrossFieldError
+	if assert.True(t, errors.As(err, &crossFieldErrors)) &&
+		assert.Equal(t, CrossFieldErrors{
+			{Kind: OneOfRule, Keys: []string{"search[email]", "search[phone]"}, Present: []string{"search[email]", "search[phone]"}},
+			{Kind: DependsOnRule, Keys: []string{"search[end_date]", "search[start_date]"}, Present: []string{"search[end_date]"}},
+		}, crossFieldErrors) &&
+		assert.EqualError(t, err, "cross-field: exactly one of `search[email]`, `search[phone]` must be present; "+
+			"`search[end_date]` requires `search[start_date]`") &&
+		assert.True(t, errors.As(err, &crossFieldError)) &&
+		assert.Equal(t, OneOfRule, crossFieldError.Kind) {
+	}
+}
+
+func Test_Permit_CrossFieldRules_ChainMethods(t *testing.T) {
+	permitted := Params().Permit("email, phone, address:{zip}").
+		AtLeastOneOf("email", "phone", "address").
+		MutuallyExclusive("email", "phone")
+
+	errNone := permitted.Values(mockQueryValues("email=&other=x"))(&searchParams{})
+	errBoth := permitted.Values(mockQueryValues("email=a@b.c&phone=123"))(&searchParams{})
+	errNested := permitted.Values(mockQueryValues("address[zip]=123"))(&searchParams{})
+
+	if assert.EqualError(t, errNone, "cross-field: at least one of `email`, `phone`, `address` must be present") &&
+		assert.EqualError(t, errBoth, "cross-field: only one of `email`, `phone` may be present") &&
+		assert.NoError(t, errNested) {
+	}
+}
+
+func Test_Permit_CrossFieldRules_ExactlyOneOf_Missing(t *testing.T) {
+	err := Params().Permit("email, phone").ExactlyOneOf("email", "phone").
+		Values(mockQueryValues("other=x"))(&searchParams{})
+
+	if assert.EqualError(t, err, "cross-field: exactly one of `email`, `phone` must be present") {
+	}
+}
+
+func Test_Permit_CrossFieldRules_Invalid(t *testing.T) {
+	errSingle := Params().Permit("email, oneOf(email)").Values(mockQueryValues("email=x"))(&searchParams{})
+	errNested := Params().Permit("address:{zip, oneOf(zip, city)}").Values(mockQueryValues("email=x"))(&searchParams{})
+	errChain := Params().Permit("email").DependsOn("email").Values(mockQueryValues("email=x"))(&searchParams{})
+
+	if assert.EqualError(t, errSingle, "cross-field: rule `oneOf` requires at least two keys") &&
+		assert.EqualError(t, errNested, "cross-field: rules are allowed only at the top level of rule "+
+			"`address:{zip, oneOf(zip, city)}`") &&
+		assert.EqualError(t, errChain, "cross-field: rule `dependsOn` requires a key and its dependencies") {
+	}
+}
+
+func Test_Parameters_Permit_CrossFieldRules(t *testing.T) {
+	_, err := NewParameters(mockQueryValues("email=a@b.c&phone=123")).Permit("email, phone, exclusive(email, phone)")
+
+	if assert.EqualError(t, err, "cross-field: only one of `email`, `phone` may be present") {
+	}
+}
+
+func Test_Permit_CrossFieldRules_WithValidator(t *testing.T) {
+	values := mockQueryValues("email=a@b.c&phone=123")
+
+	err := Params().WithValidator(ValidatorFunc(func(target interface{}, aliasTag string) error {
+		return ValidationErrors{{Path: "email", Message: "is taken"}}
+	})).Permit("email, phone, oneOf(email, phone)").Values(values)(&searchParams{})
+
+	var crossFieldErrors CrossFieldErrors
+	var validationErrors ValidationErrors
+	if assert.True(t, errors.As(err, &crossFieldErrors)) &&
+		assert.Len(t, crossFieldErrors, 1) &&
+		assert.True(t, errors.As(err, &validationErrors)) &&
+		assert.Equal(t, ValidationErrors{{Path: "email", Message: "is taken"}}, validationErrors) {
+	}
+}
@@ -0,0 +1,101 @@
+package strongparamstest
+
+import (
+	"github.com/stretchr/testify/assert"
+	. "github.com/vellotis/go-strongparams"
+	"testing"
+)
+
+type listParams struct {
+	Page    int      `params:"page"`
+	PerPage int      `params:"per_page"`
+	Sort    string   `params:"sort"`
+	Query   string   `params:"q"`
+	Status  []string `params:"status"`
+}
+
+func Test_Permit_Defaults_DSL(t *testing.T) {
+	result := listParams{}
+
+	err := Params().Permit("page=1, per_page=25, sort=created_at, q, status:[]").
+		Values(mockQueryValues("per_page=50&sort=&q=abc"))(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, listParams{Page: 1, PerPage: 50, Sort: "created_at", Query: "abc"}, result) {
+	}
+}
+
+func Test_Require_Permit_Defaults_ChainMethod(t *testing.T) {
+	result := listParams{}
+
+	err := Params().Require("list").Permit("page, per_page, status:[]").
+		Default("per_page", "25").
+		Default("status", "draft", "published").
+		Values(mockQueryValues("list[page]=2"))(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, listParams{Page: 2, PerPage: 25, Status: []string{"draft", "published"}}, result) {
+	}
+}
+
+func Test_Permit_Defaults_ArrayTree(t *testing.T) {
+	var tree Tree
+
+	err := Params().Permit("status:[]").Default("status", "draft").Values(mockQueryValues(""))(&tree)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, Tree{"status": []interface{}{"draft"}}, tree) {
+	}
+}
+
+func Test_Permit_Defaults_NotPermitted(t *testing.T) {
+	var tree Tree
+
+	err := Params().Permit("name").Default("role", "admin").Values(mockQueryValues("name=John"))(&tree)
+
+	if assert.EqualError(t, err, "defaults: default key `role` is not permitted") &&
+		assert.Nil(t, tree) {
+	}
+}
+
+func Test_Require_Permit_Defaults_Tree(t *testing.T) {
+	var tree Tree
+
+	err := Params().Require("list").Permit("page='1', sort=created_at, status:(draft|published)").
+		Values(mockQueryValues("list[status]=draft"))(&tree)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, Tree{"page": "1", "sort": "created_at", "status": "draft"}, tree) {
+	}
+}
+
+func Test_Permit_Defaults_CrossFieldRules(t *testing.T) {
+	result := searchParams{}
+
+	err := Params().Permit("start_date=2024-01-01, end_date, dependsOn(end_date, start_date)").
+		Values(mockQueryValues("end_date=2024-02-01"))(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, "2024-01-01", result.StartDate) {
+	}
+}
+
+func Test_Permit_Defaults_Invalid(t *testing.T) {
+	errEmpty := Params().Permit("page=").Values(mockQueryValues(""))(&listParams{})
+	errNested := Params().Permit("filter:{page=1}").Values(mockQueryValues(""))(&listParams{})
+	errKey := Params().Permit("page").Default("", "1").Values(mockQueryValues(""))(&listParams{})
+
+	if assert.EqualError(t, errEmpty, "defaults: empty default value of key `page`") &&
+		assert.EqualError(t, errNested, "defaults: default values are allowed only at the top level of rule "+
+			"`filter:{page=1}`") &&
+		assert.EqualError(t, errKey, "defaults: default key cannot be empty") {
+	}
+}
+
+func Test_Parameters_Permit_Defaults(t *testing.T) {
+	params, err := NewParameters(mockQueryValues("q=abc")).Permit("q, per_page=25")
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, "25", params.Fetch("per_page", nil)) {
+	}
+}
@@ -0,0 +1,125 @@
+package strongparamstest
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	. "github.com/vellotis/go-strongparams"
+	"net"
+	"testing"
+)
+
+type duplicatesProfile struct {
+	Name    string   `params:"name"`
+	Age     *int     `params:"age"`
+	Tags    []string `params:"tags"`
+	IP      net.IP   `params:"ip"`
+	Address struct {
+		City string `params:"city"`
+	} `params:"address"`
+}
+
+func Test_Permit_WithDuplicatePolicy_Default(t *testing.T) {
+	values := mockQueryValues("name=a&name=b&age=1&age=2&tags[]=x&tags[]=y")
+	result := duplicatesProfile{}
+
+	err := Params().Permit("name, age, tags:[]").Values(values)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, "b", result.Name) &&
+		assert.Equal(t, 2, *result.Age) &&
+		assert.Equal(t, []string{"x", "y"}, result.Tags) {
+	}
+}
+
+func Test_Permit_WithDuplicatePolicy_First(t *testing.T) {
+	values := mockQueryValues("name=a&name=b&tags[]=x&tags[]=y")
+	result := duplicatesProfile{}
+
+	err := Params().WithDuplicatePolicy(DuplicateFirst).Permit("name, tags:[]").Values(values)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, "a", result.Name) &&
+		assert.Equal(t, []string{"x", "y"}, result.Tags) {
+	}
+}
+
+func Test_Permit_WithDuplicatePolicy_Last(t *testing.T) {
+	values := mockQueryValues("name=a&name=b&age=1&age=2&address[city]=x&address[city]=y&tags[]=x&tags[]=y")
+	result := duplicatesProfile{}
+
+	err := Params().WithDuplicatePolicy(DuplicateLast).Permit("name, age, address:{city}, tags:[]").Values(values)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, "b", result.Name) &&
+		assert.Equal(t, 2, *result.Age) &&
+		assert.Equal(t, "y", result.Address.City) &&
+		assert.Equal(t, []string{"x", "y"}, result.Tags) {
+	}
+}
+
+func Test_Require_Permit_WithDuplicatePolicy_Error(t *testing.T) {
+	values := mockQueryValues("user[name]=a&user[name]=b&user[address][city]=x&user[address][city]=y&user[tags][]=x&user[tags][]=y&user[ip]=127.0.0.1&user[ip]=::1")
+	result := duplicatesProfile{}
+
+	err := Params().WithDuplicatePolicy(DuplicateError).Require("user").Permit("name, ip, address:{city}, tags:[]").Values(values)(&result)
+
+	var duplicateErr *DuplicateKeysError
+	if assert.True(t, errors.As(err, &duplicateErr)) &&
+		assert.Equal(t, []string{"address[city]", "ip", "name"}, duplicateErr.Keys) &&
+		assert.EqualError(t, err, "duplicate values of scalar keys: `address[city]`, `ip`, `name`") {
+	}
+}
+
+func Test_Permit_WithDuplicatePolicy_Error_UnpermittedIgnored(t *testing.T) {
+	values := mockQueryValues("name=a&admin=1&admin=2")
+	result := duplicatesProfile{}
+
+	err := Params().WithDuplicatePolicy(DuplicateError).Permit("name").Values(values)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, "a", result.Name) {
+	}
+}
+
+func Test_Permit_WithDuplicatePolicy_Join(t *testing.T) {
+	values := mockQueryValues("name=a&name=b")
+	result := duplicatesProfile{}
+
+	err := Params().WithDuplicatePolicy(DuplicateJoin).Permit("name").Values(values)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, "a,b", result.Name) {
+	}
+}
+
+func Test_Permit_WithDuplicatePolicy_Tree(t *testing.T) {
+	values := mockQueryValues("name=a&name=b")
+	result := Tree{}
+
+	err := Params().WithDuplicatePolicy(DuplicateError).Permit("name").Values(values)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, Tree{"name": []interface{}{"a", "b"}}, result) {
+	}
+}
+
+func Test_RequireOne_WithDuplicatePolicy(t *testing.T) {
+	values := mockQueryValues("id=1&id=2")
+	parser := func(value string) (string, error) {
+		return value, nil
+	}
+
+	first, errFirst := Params().RequireOne("id").Values(values)(parser)
+	explicitFirst, errExplicitFirst := Params().WithDuplicatePolicy(DuplicateFirst).RequireOne("id").Values(values)(parser)
+	last, errLast := Params().WithDuplicatePolicy(DuplicateLast).RequireOne("id").Values(values)(parser)
+	_, errDuplicate := Params().WithDuplicatePolicy(DuplicateError).RequireOne("id").Values(values)(parser)
+
+	if assert.NoError(t, errFirst) &&
+		assert.Equal(t, "1", first) &&
+		assert.NoError(t, errExplicitFirst) &&
+		assert.Equal(t, "1", explicitFirst) &&
+		assert.NoError(t, errLast) &&
+		assert.Equal(t, "2", last) &&
+		assert.EqualError(t, errDuplicate, "duplicate values of scalar keys: `id`") {
+	}
+}
@@ -0,0 +1,117 @@
+package strongparamstest
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	. "github.com/vellotis/go-strongparams"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type rackItem struct {
+	Name  string   `params:"name"`
+	Price int      `params:"price"`
+	Tags  []string `params:"tags"`
+}
+
+type rackOrder struct {
+	Items []rackItem `params:"items"`
+}
+
+func Test_Require_Permit_EmptyBracketArrayOfObjects(t *testing.T) {
+	request := mockRequestWithQuery("order[items][][name]=a&order[items][][admin]=true&order[items][][name]=b&" +
+		"order[items][][price]=2")
+	result := rackOrder{}
+
+	err := Params().Require("order").Permit("items:[name, price]").Query(request)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, rackOrder{Items: []rackItem{{Name: "a"}, {Name: "b", Price: 2}}}, result) {
+	}
+}
+
+func Test_Permit_EmptyBracketArrayOfObjects_NestedArray(t *testing.T) {
+	result := rackOrder{}
+
+	err := Params().Permit("items:[name, tags:[]]").
+		Raw("items[][name]=a&items[][tags][]=x&items[][tags][]=y&items[][name]=b")(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, rackOrder{Items: []rackItem{{Name: "a", Tags: []string{"x", "y"}}, {Name: "b"}}}, result) {
+	}
+}
+
+func Test_Permit_EmptyBracketArrayOfObjects_NestedObject(t *testing.T) {
+	var tree Tree
+
+	err := Params().Permit("items:[name, address:{city, zip}]").
+		Raw("items[][address][city]=x&items[][address][zip]=1&items[][address][city]=y&items[][name]=b")(&tree)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, Tree{"items": []interface{}{
+			Tree{"address": Tree{"city": "x", "zip": "1"}},
+			Tree{"address": Tree{"city": "y"}, "name": "b"},
+		}}, tree) {
+	}
+}
+
+func Test_Permit_EmptyBracketArrayOfObjects_Tree(t *testing.T) {
+	var tree Tree
+
+	err := Params().Permit("items:[name, price]").Raw("items[][name]=a&items[][price]=1&items[][name]=b")(&tree)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, Tree{"items": []interface{}{
+			Tree{"name": "a", "price": "1"},
+			Tree{"name": "b"},
+		}}, tree) {
+	}
+}
+
+func Test_Permit_EmptyBracketArrayOfObjects_FromMultipart(t *testing.T) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	_ = writer.WriteField("items[][name]", "a")
+	fileWriter, _ := writer.CreateFormFile("items[][file]", "a.txt")
+	_, _ = fileWriter.Write([]byte("a"))
+	_ = writer.WriteField("items[][name]", "b")
+	_ = writer.Close()
+	request := httptest.NewRequest(http.MethodPost, "/", body)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	result := struct {
+		Items []struct {
+			Name string                `params:"name"`
+			File *multipart.FileHeader `params:"file"`
+		} `params:"items"`
+	}{}
+
+	err := Params().Permit("items:[name, file]").Multipart(request, MultipartLimits{})(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Len(t, result.Items, 2) &&
+		assert.Equal(t, "a", result.Items[0].Name) &&
+		assert.Equal(t, "a.txt", result.Items[0].File.Filename) &&
+		assert.Equal(t, "b", result.Items[1].Name) &&
+		assert.Nil(t, result.Items[1].File) {
+	}
+}
+
+func Test_Permit_EmptyBracketArrayOfObjects_FromValues(t *testing.T) {
+	single := rackOrder{}
+	errSingle := Params().Permit("items:[name, price, tags:[]]").
+		Values(mockQueryValues("items[][name]=a&items[][price]=1&items[][tags][]=x&items[][tags][]=y"))(&single)
+	repeated := rackOrder{}
+	errRepeated := Params().Permit("items:[name]").Values(mockQueryValues("items[][name]=a&items[][name]=b"))(&repeated)
+	errAmbiguous := Params().Permit("items:[name, price]").
+		Values(mockQueryValues("items[][name]=a&items[][name]=b&items[][price]=2"))(&rackOrder{})
+
+	if assert.NoError(t, errSingle) &&
+		assert.Equal(t, rackOrder{Items: []rackItem{{Name: "a", Price: 1, Tags: []string{"x", "y"}}}}, single) &&
+		assert.NoError(t, errRepeated) &&
+		assert.Equal(t, rackOrder{Items: []rackItem{{Name: "a"}, {Name: "b"}}}, repeated) &&
+		assert.EqualError(t, errAmbiguous, "the objects of array `items[]` depend on the order of the keys which is "+
+			"not preserved by url.Values") {
+	}
+}
@@ -0,0 +1,145 @@
+package strongparamstest
+
+import (
+	"github.com/gorilla/schema"
+	"github.com/stretchr/testify/assert"
+	. "github.com/vellotis/go-strongparams"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type tenant struct {
+	Name string
+}
+
+type apiHeaders struct {
+	Version  int    `header:"X-Api-Version"`
+	TenantID string `header:"X-Tenant-Id"`
+	Secret   string `header:"X-Secret"`
+}
+
+func mockRequestWithHeaders() *http.Request {
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("x-api-version", "2")
+	request.Header.Set("X-Tenant-ID", "acme")
+	request.Header.Set("X-Secret", "ignored")
+	request.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+	request.AddCookie(&http.Cookie{Name: "theme", Value: "dark"})
+	return request
+}
+
+func Test_Permit_FromHeaders(t *testing.T) {
+	result := apiHeaders{}
+
+	err := Params().Permit("X-Api-Version, X-Tenant-Id").Headers(mockRequestWithHeaders())(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, apiHeaders{Version: 2, TenantID: "acme"}, result) {
+	}
+}
+
+func Test_Params_FromHeaders_UnknownKeysIgnored(t *testing.T) {
+	result := apiHeaders{}
+
+	err := Params().Headers(mockRequestWithHeaders())(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, apiHeaders{Version: 2, TenantID: "acme", Secret: "ignored"}, result) {
+	}
+}
+
+func Test_Permit_FromCookies(t *testing.T) {
+	type Cookies struct {
+		Session string `cookie:"session"`
+		Theme   string `cookie:"theme"`
+	}
+	result := Cookies{}
+
+	err := Params().Permit("session").Cookies(mockRequestWithHeaders())(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, Cookies{Session: "abc"}, result) {
+	}
+}
+
+func Test_RequireOne_FromHeaders(t *testing.T) {
+	value, err := Params().RequireOne("X-Tenant-Id").Headers(mockRequestWithHeaders())(func(value string) (string, error) {
+		return value, nil
+	})
+	_, errMissing := Params().RequireOne("X-Missing").Headers(mockRequestWithHeaders())(func(value string) (string, error) {
+		return value, nil
+	})
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, "acme", value) &&
+		assert.EqualError(t, errMissing, "query: missing required key: `X-Missing`") {
+	}
+}
+
+func Test_Permit_FromSources_HeadersAndCookies(t *testing.T) {
+	type Context struct {
+		TenantID string `params:"X-Tenant-Id"`
+		Session  string `params:"session"`
+	}
+	request := mockRequestWithHeaders()
+	result := Context{}
+
+	err := Params().Permit("X-Tenant-Id, session").Sources(Headers(request), Cookies(request))(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, Context{TenantID: "acme", Session: "abc"}, result) {
+	}
+}
+
+func Test_PermitStruct_FromHeaders(t *testing.T) {
+	type Headers struct {
+		TenantID string `header:"X-Tenant-Id"`
+		Secret   string `header:"X-Secret" permit:"-"`
+	}
+	decoder := schema.NewDecoder()
+	decoder.SetAliasTag(HeaderTag)
+	result := Headers{}
+
+	err := Params().WithDecoder(decoder).WithAliasTag(HeaderTag).PermitStruct(&result).
+		Headers(mockRequestWithHeaders())(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, Headers{TenantID: "acme"}, result) {
+	}
+}
+
+func Test_WithDecoder_FromHeadersAndCookies(t *testing.T) {
+	decoder := schema.NewDecoder()
+	decoder.SetAliasTag("custom")
+	decoder.RegisterConverter(tenant{}, func(value string) reflect.Value {
+		return reflect.ValueOf(tenant{Name: strings.ToUpper(value)})
+	})
+	type Context struct {
+		Tenant  tenant `header:"X-Tenant-Id" custom:"tenant"`
+		Session string `cookie:"session"`
+		Secret  string `header:"X-Secret" custom:"-"`
+	}
+	result := Context{}
+
+	errHeaders := Params().WithDecoder(decoder).WithAliasTag("custom").Headers(mockRequestWithHeaders())(&result)
+	errCookies := Params().WithDecoder(decoder).WithAliasTag("custom").Cookies(mockRequestWithHeaders())(&result)
+
+	if assert.NoError(t, errHeaders) &&
+		assert.NoError(t, errCookies) &&
+		assert.Equal(t, Context{Tenant: tenant{Name: "ACME"}, Session: "abc"}, result) {
+	}
+}
+
+func Test_Params_FromHeaders_Required(t *testing.T) {
+	type Required struct {
+		Trace string `header:"X-Trace-Id,required"`
+	}
+
+	err := Params().Headers(mockRequestWithHeaders())(&Required{})
+
+	if assert.EqualError(t, err, "X-Trace-Id is empty") {
+	}
+}
@@ -0,0 +1,118 @@
+package strongparamstest
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	. "github.com/vellotis/go-strongparams"
+	"strconv"
+	"testing"
+)
+
+func Test_Params_FromJSON(t *testing.T) {
+	request := mockRequestWithJSON(`{"root":{"key":"value","number":1.5,"flag":true,"tags":["tag1","tag2"]}}`)
+	type RootValue struct {
+		Root struct {
+			Key    string   `params:"key"`
+			Number float64  `params:"number"`
+			Flag   bool     `params:"flag"`
+			Tags   []string `params:"tags"`
+		} `params:"root"`
+	}
+	result := RootValue{}
+
+	err := Params().JSON(request)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, "value", result.Root.Key) &&
+		assert.Equal(t, 1.5, result.Root.Number) &&
+		assert.True(t, result.Root.Flag) &&
+		assert.Equal(t, []string{"tag1", "tag2"}, result.Root.Tags) {
+	}
+}
+
+func Test_Require_FromJSON_NullLeavesFieldUntouched(t *testing.T) {
+	request := mockRequestWithJSON(`{"root":{"key":null,"other":"value"}}`)
+	type KeyValue struct {
+		Key   *string `params:"key"`
+		Other string  `params:"other"`
+	}
+	result := KeyValue{}
+
+	err := Params().Require("root").JSON(request)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Nil(t, result.Key) &&
+		assert.Equal(t, "value", result.Other) {
+	}
+}
+
+func Test_Require_Permit_FromJSON(t *testing.T) {
+	request := mockRequestWithJSON(`{"root":{"arr":[{"key":"key1","value":"value1"},{"key":"key2"}],"ignored":"value"}}`)
+	type Struct struct {
+		Arr []struct {
+			Key   string `params:"key"`
+			Value string `params:"value"`
+		} `params:"arr"`
+	}
+	result := Struct{}
+
+	err := Params().Require("root").Permit("arr:[key,value]").JSON(request)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, 2, len(result.Arr)) &&
+		assert.Equal(t, "key1", result.Arr[0].Key) &&
+		assert.Equal(t, "value1", result.Arr[0].Value) &&
+		assert.Equal(t, "key2", result.Arr[1].Key) {
+	}
+}
+
+func Test_Require_Permit_FromJSON_TreePreservesTypes(t *testing.T) {
+	request := mockRequestWithJSON(`{"root":{"number":10,"flag":false,"null":null,"text":"1","arr":[1,"a"],"ignored":1}}`)
+	result := Tree{}
+
+	err := Params().Require("root").Permit("number, flag, null, text, arr:[]").JSON(request)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, Tree{
+			"number": json.Number("10"),
+			"flag":   false,
+			"null":   nil,
+			"text":   "1",
+			"arr":    []interface{}{json.Number("1"), "a"},
+		}, result) {
+	}
+}
+
+func Test_RequireOne_FromJSON(t *testing.T) {
+	request := mockRequestWithJSON(`{"key":4}`)
+
+	value, err := Params().RequireOne("key").JSON(request)(strconv.Atoi)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, 4, value.(int)) {
+	}
+}
+
+func Test_Params_FromJSON_InvalidDocument(t *testing.T) {
+	result := Tree{}
+
+	errArray := Params().JSON(mockRequestWithJSON(`["value"]`))(&result)
+	errSyntax := Params().Require("root").JSON(mockRequestWithJSON(`{"root":`))(&result)
+
+	if assert.EqualError(t, errArray, "json: request body must be a JSON object") &&
+		assert.EqualError(t, errSyntax, "json: failed to parse request body: unexpected EOF") {
+	}
+}
+
+func Test_Params_FromJSON_MaxBodyBytes(t *testing.T) {
+	result := Tree{}
+
+	errLimit := Params().WithRequestLimits(RequestLimits{MaxBodyBytes: 10}).
+		Require("root").Permit("key").JSON(mockRequestWithJSON(`{"root":{"key":"value"}}`))(&result)
+	errUnlimited := Params().WithRequestLimits(RequestLimits{MaxBodyBytes: -1}).
+		JSON(mockRequestWithJSON(`{"root":{"key":"value"}}`))(&result)
+
+	if assert.Equal(t, &LimitError{Limit: "MaxBodyBytes", Max: 10}, errLimit) &&
+		assert.NoError(t, errUnlimited) {
+	}
+}
@@ -0,0 +1,72 @@
+package strongparamstest
+
+import (
+	"github.com/stretchr/testify/assert"
+	. "github.com/vellotis/go-strongparams"
+	"testing"
+)
+
+type keyCaseUser struct {
+	FirstName   string `params:"first_name"`
+	HomeAddress struct {
+		ZipCode string `params:"zip_code"`
+	} `params:"home_address"`
+	Items []struct {
+		UserID  string `params:"user_id"`
+		Destroy bool   `params:"_destroy"`
+	} `params:"items"`
+}
+
+func Test_Require_Permit_WithKeyCase_Snake(t *testing.T) {
+	rules := "first_name, home_address:{zip_code}, items:[user_id, _destroy]"
+	camel := mockQueryValues("user[firstName]=John&user[homeAddress][zipCode]=10115&user[items][0][userID]=1&user[items][0][_destroy]=true")
+	snake := mockQueryValues("user[first_name]=John&user[home_address][zip_code]=10115&user[items][0][user_id]=1&user[items][0][_destroy]=true")
+	resultCamel, resultSnake := keyCaseUser{}, keyCaseUser{}
+
+	errCamel := Params().WithKeyCase(SnakeCase).Require("user").Permit(rules).Values(camel)(&resultCamel)
+	errSnake := Params().WithKeyCase(SnakeCase).Require("user").Permit(rules).Values(snake)(&resultSnake)
+
+	if assert.NoError(t, errCamel) &&
+		assert.NoError(t, errSnake) &&
+		assert.Equal(t, "John", resultCamel.FirstName) &&
+		assert.Equal(t, "10115", resultCamel.HomeAddress.ZipCode) &&
+		assert.Len(t, resultCamel.Items, 1) &&
+		assert.Equal(t, "1", resultCamel.Items[0].UserID) &&
+		assert.True(t, resultCamel.Items[0].Destroy) &&
+		assert.Equal(t, resultCamel, resultSnake) {
+	}
+}
+
+func Test_Permit_WithKeyCase_Tree(t *testing.T) {
+	values := mockQueryValues("first_name=John&home-address[zip-code]=10115&HTTPServer=x")
+	camel, kebab := Tree{}, Tree{}
+
+	errCamel := Params().WithKeyCase(CamelCase).Permit("firstName, homeAddress:{zipCode}, httpServer").Values(values)(&camel)
+	errKebab := Params().WithKeyCase(KebabCase).Permit("first-name, home-address:{zip-code}, http-server").Values(values)(&kebab)
+
+	if assert.NoError(t, errCamel) &&
+		assert.Equal(t, Tree{"firstName": "John", "homeAddress": Tree{"zipCode": "10115"}, "httpServer": "x"}, camel) &&
+		assert.NoError(t, errKebab) &&
+		assert.Equal(t, Tree{"first-name": "John", "home-address": Tree{"zip-code": "10115"}, "http-server": "x"}, kebab) {
+	}
+}
+
+func Test_Permit_WithKeyCase_Collision(t *testing.T) {
+	values := mockQueryValues("user[firstName]=John&user[first_name]=Jane")
+
+	err := Params().WithKeyCase(SnakeCase).Require("user").Permit("first_name").Values(values)(&keyCaseUser{})
+
+	if assert.EqualError(t, err, "key case: keys `user[firstName]` and `user[first_name]` convert to the same key `user[first_name]`") {
+	}
+}
+
+func Test_RequireOne_WithKeyCase(t *testing.T) {
+	value, err := Params().WithKeyCase(SnakeCase).RequireOne("user_id").Values(mockQueryValues("userId=7"))(
+		func(value string) (string, error) {
+			return value, nil
+		})
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, "7", value) {
+	}
+}
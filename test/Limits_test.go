@@ -0,0 +1,77 @@
+package strongparamstest
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	. "github.com/vellotis/go-strongparams"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+type limitsUser struct {
+	Name string   `params:"name"`
+	Tags []string `params:"tags"`
+}
+
+func Test_Require_Permit_DefaultLimits_MaxArrayIndex(t *testing.T) {
+	values := mockQueryValues("user[tags][99999999]=x")
+	result := limitsUser{}
+
+	err := Params().Require("user").Permit("tags:[]").Values(values)(&result)
+
+	var limitErr *LimitError
+	if assert.True(t, errors.As(err, &limitErr)) &&
+		assert.Equal(t, LimitError{Limit: "MaxArrayIndex", Key: "user[tags][99999999]", Max: 1000}, *limitErr) &&
+		assert.Nil(t, result.Tags) {
+	}
+}
+
+func Test_Permit_WithLimits(t *testing.T) {
+	tests := []struct {
+		limits Limits
+		values url.Values
+		err    LimitError
+	}{
+		{Limits{MaxKeys: 2}, mockQueryValues("a=1&b=2&c=3"), LimitError{Limit: "MaxKeys", Max: 2}},
+		{Limits{MaxDepth: 2}, mockQueryValues("a[b][c]=1"), LimitError{Limit: "MaxDepth", Key: "a[b][c]", Max: 2}},
+		{Limits{MaxArrayIndex: 5}, mockQueryValues("a[b][6][c]=1"), LimitError{Limit: "MaxArrayIndex", Key: "a[b][6][c]", Max: 5}},
+		{Limits{MaxArrayLen: 2}, mockQueryValues("tags[]=1&tags[]=2&tags[]=3"), LimitError{Limit: "MaxArrayLen", Key: "tags", Max: 2}},
+		{Limits{MaxArrayLen: 2}, mockQueryValues("tags=1&tags=2&tags=3"), LimitError{Limit: "MaxArrayLen", Key: "tags", Max: 2}},
+		{Limits{MaxArrayLen: 2}, mockQueryValues("items[0][a]=1&items[1][a]=2&items[2][a]=3"), LimitError{Limit: "MaxArrayLen", Key: "items", Max: 2}},
+		{Limits{MaxValueBytes: 3}, mockQueryValues("name=abcd"), LimitError{Limit: "MaxValueBytes", Key: "name", Max: 3}},
+		{Limits{MaxKeyBytes: 3}, mockQueryValues("name=a"), LimitError{Limit: "MaxKeyBytes", Key: "name", Max: 3}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.err.Limit, func(t *testing.T) {
+			err := Params().WithLimits(test.limits).Permit("name").Values(test.values)(&limitsUser{})
+
+			var limitErr *LimitError
+			if assert.True(t, errors.As(err, &limitErr)) &&
+				assert.Equal(t, test.err, *limitErr) {
+			}
+		})
+	}
+}
+
+func Test_Permit_WithLimits_Disabled(t *testing.T) {
+	values := mockQueryValues("name=%s&tags[1001]=x", strings.Repeat("a", 2<<20))
+	result := limitsUser{}
+
+	err := Params().WithLimits(Limits{MaxValueBytes: -1, MaxArrayIndex: -1}).Permit("name").Values(values)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Len(t, result.Name, 2<<20) {
+	}
+}
+
+func Test_RequireOne_WithLimits(t *testing.T) {
+	_, err := Params().WithLimits(Limits{MaxValueBytes: 1}).RequireOne("id").Values(mockQueryValues("id=12"))(
+		func(value string) (string, error) {
+			return value, nil
+		})
+
+	if assert.EqualError(t, err, "limit `MaxValueBytes` of 1 exceeded by key `id`") {
+	}
+}
@@ -0,0 +1,216 @@
+package strongparamstest
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	. "github.com/vellotis/go-strongparams"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+type multipartFile struct {
+	key      string
+	fileName string
+	content  string
+}
+
+func mockMultipartRequest(fields map[string]string, files ...multipartFile) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for key, value := range fields {
+		_ = writer.WriteField(key, value)
+	}
+	for _, file := range files {
+		fileWriter, _ := writer.CreateFormFile(file.key, file.fileName)
+		_, _ = fileWriter.Write([]byte(file.content))
+	}
+	_ = writer.Close()
+
+	request := httptest.NewRequest(http.MethodPost, "/", body)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	return request
+}
+
+func Test_Require_Permit_FromMultipart(t *testing.T) {
+	request := mockMultipartRequest(
+		map[string]string{"root[name]": "avatar", "root[ignored]": "value"},
+		multipartFile{key: "root[file]", fileName: "avatar.png", content: "image"},
+		multipartFile{key: "root[reader]", fileName: "reader.txt", content: "text"},
+		multipartFile{key: "root[unpermitted]", fileName: "ignored.txt", content: "ignored"},
+	)
+	type Avatar struct {
+		Name   string                `params:"name"`
+		File   *multipart.FileHeader `params:"file"`
+		Reader io.Reader             `params:"reader"`
+	}
+	result := Avatar{}
+
+	err := Params().Require("root").Permit("name, file, reader").Multipart(request, MultipartLimits{})(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, "avatar", result.Name) &&
+		assert.NotNil(t, result.File) &&
+		assert.NotNil(t, result.Reader) {
+		assert.Equal(t, "avatar.png", result.File.Filename)
+		file, _ := result.File.Open()
+		content, _ := ioutil.ReadAll(file)
+		assert.Equal(t, "image", string(content))
+		readerContent, _ := ioutil.ReadAll(result.Reader)
+		assert.Equal(t, "text", string(readerContent))
+	}
+}
+
+func Test_Params_FromMultipart_MultipleFiles(t *testing.T) {
+	request := mockMultipartRequest(
+		nil,
+		multipartFile{key: "files", fileName: "file1.txt", content: "1"},
+		multipartFile{key: "files", fileName: "file2.txt", content: "2"},
+	)
+	type Files struct {
+		Files []*multipart.FileHeader `params:"files"`
+	}
+	result := Files{}
+
+	err := Params().Multipart(request, MultipartLimits{})(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Len(t, result.Files, 2) {
+	}
+}
+
+func Test_Permit_FromMultipart_UnpermittedPartsAreNotLimited(t *testing.T) {
+	request := mockMultipartRequest(
+		map[string]string{"key": "value", "ignored": "too long value"},
+		multipartFile{key: "ignoredFile", fileName: "ignored.txt", content: "too long content"},
+	)
+	type KeyValue struct {
+		Key string `params:"key"`
+	}
+	result := KeyValue{}
+
+	err := Params().Permit("key").Multipart(request, MultipartLimits{MaxFieldBytes: 5, MaxFileBytes: 5})(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, "value", result.Key) {
+	}
+}
+
+func Test_Permit_FromMultipart_LimitsExceeded(t *testing.T) {
+	type File struct {
+		Key  string                `params:"key"`
+		File *multipart.FileHeader `params:"file"`
+	}
+	result := File{}
+
+	errField := Params().Permit("key").Multipart(
+		mockMultipartRequest(map[string]string{"key": "too long value"}),
+		MultipartLimits{MaxFieldBytes: 5},
+	)(&result)
+	errFile := Params().Permit("file").Multipart(
+		mockMultipartRequest(nil, multipartFile{key: "file", fileName: "file.txt", content: "too long content"}),
+		MultipartLimits{MaxFileBytes: 5},
+	)(&result)
+	errTotal := Params().Permit("key, file").Multipart(
+		mockMultipartRequest(map[string]string{"key": "value"}, multipartFile{key: "file", fileName: "file.txt", content: "content"}),
+		MultipartLimits{MaxTotalBytes: 10},
+	)(&result)
+
+	if assert.Equal(t, &LimitError{Limit: "MaxFieldBytes", Key: "key", Max: 5}, errField) &&
+		assert.Equal(t, &LimitError{Limit: "MaxFileBytes", Key: "file", Max: 5}, errFile) &&
+		assert.EqualError(t, errTotal, "limit `MaxTotalBytes` of 10 exceeded by key `file`") {
+	}
+}
+
+func Test_RequireOne_FromMultipart(t *testing.T) {
+	request := mockMultipartRequest(map[string]string{"key": "4", "ignored": "value"})
+
+	value, err := Params().RequireOne("key").Multipart(request, MultipartLimits{})(func(value string) (string, error) {
+		return value, nil
+	})
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, "4", value) {
+	}
+}
+
+func Test_Params_FromMultipart_TemporaryFilesRemoved(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("TMPDIR", tempDir)
+	request := mockMultipartRequest(
+		map[string]string{"name": "avatar"},
+		multipartFile{key: "small", fileName: "small.txt", content: "1234"},
+		multipartFile{key: "large", fileName: "large.txt", content: "large content"},
+	)
+	result := Tree{}
+
+	err := Params().Multipart(request, MultipartLimits{MaxMemory: 4})(&result)
+	remaining, _ := os.ReadDir(tempDir)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, "avatar", result["name"]) &&
+		assert.Empty(t, remaining) {
+	}
+}
+
+func Test_Require_Permit_FromMultipart_BoundTemporaryFiles(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("TMPDIR", tempDir)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	request := mockMultipartRequest(
+		nil,
+		multipartFile{key: "root[file]", fileName: "file.txt", content: "file content"},
+		multipartFile{key: "root[reader]", fileName: "reader.txt", content: "reader content"},
+	).WithContext(ctx)
+	type Upload struct {
+		File   *multipart.FileHeader `params:"file"`
+		Reader io.Reader             `params:"reader"`
+	}
+	result := Upload{}
+
+	err := Params().Require("root").Permit("file, reader").Multipart(request, MultipartLimits{MaxMemory: 4})(&result)
+	spilled, _ := os.ReadDir(tempDir)
+
+	if assert.NoError(t, err) &&
+		assert.Len(t, spilled, 2) {
+		readerContent, _ := ioutil.ReadAll(result.Reader)
+		_ = result.Reader.(io.Closer).Close()
+		afterClose, _ := os.ReadDir(tempDir)
+		file, _ := result.File.Open()
+		content, _ := ioutil.ReadAll(file)
+		_ = file.Close()
+		cancel()
+
+		assert.Equal(t, "reader content", string(readerContent))
+		assert.Len(t, afterClose, 1)
+		assert.Equal(t, "file content", string(content))
+		assert.Eventually(t, func() bool {
+			remaining, _ := os.ReadDir(tempDir)
+			return len(remaining) == 0
+		}, time.Second, 10*time.Millisecond)
+	}
+}
+
+func Test_Params_FromMultipart_TemporaryFilesRemovedOnError(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("TMPDIR", tempDir)
+	request := mockMultipartRequest(
+		nil,
+		multipartFile{key: "file", fileName: "large.txt", content: "large content"},
+		multipartFile{key: "other", fileName: "other.txt", content: "other content"},
+	)
+
+	err := Params().Multipart(request, MultipartLimits{MaxMemory: 4, MaxTotalBytes: 20})(&Tree{})
+	remaining, _ := os.ReadDir(tempDir)
+
+	if assert.Equal(t, &LimitError{Limit: "MaxTotalBytes", Key: "other", Max: 20}, err) &&
+		assert.Empty(t, remaining) {
+	}
+}
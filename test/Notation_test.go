@@ -0,0 +1,104 @@
+package strongparamstest
+
+import (
+	"github.com/stretchr/testify/assert"
+	. "github.com/vellotis/go-strongparams"
+	"testing"
+)
+
+type notationAddress struct {
+	City string `params:"city"`
+}
+
+type notationUser struct {
+	Name    string            `params:"name"`
+	Address notationAddress   `params:"address"`
+	Items   []notationAddress `params:"items"`
+}
+
+func Test_Permit_UnpermittedDottedKeysAreDropped(t *testing.T) {
+	values := mockQueryValues("name=John&junk.key=value")
+	result := notationUser{}
+
+	err := Params().Permit("name").Values(values)(&result)
+	errUnpermitted := Params().Values(values)(&notationUser{})
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, notationUser{Name: "John"}, result) &&
+		assert.Error(t, errUnpermitted) {
+	}
+}
+
+func Test_Require_Permit_WithNotation_Dots(t *testing.T) {
+	values := mockQueryValues("user.name=John&user.address.city=Tallinn&user.items.0.city=Tartu&user.admin=true")
+	result := notationUser{}
+
+	err := Params().WithNotation(Dots).Require("user").Permit("name, address:{city}, items:[city]").Values(values)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, notationUser{
+			Name:    "John",
+			Address: notationAddress{City: "Tallinn"},
+			Items:   []notationAddress{{City: "Tartu"}},
+		}, result) {
+	}
+}
+
+func Test_Require_Permit_WithNotation_Dots_FromJSON(t *testing.T) {
+	request := mockRequestWithJSON(`{"user":{"name":"John","address":{"city":"Tallinn"},"admin":true}}`)
+	result := notationUser{}
+
+	err := Params().WithNotation(Dots).Require("user").Permit("name, address:{city}").JSON(request)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, notationUser{Name: "John", Address: notationAddress{City: "Tallinn"}}, result) {
+	}
+}
+
+func Test_Require_Permit_WithNotation_Dots_FromMultipart(t *testing.T) {
+	request := mockMultipartRequest(map[string]string{"user[name]": "John", "user.address.city": "Tallinn"})
+	result := notationUser{}
+
+	err := Params().WithNotation(Dots).Require("user").Permit("name, address:{city}").
+		Multipart(request, MultipartLimits{})(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, notationUser{Name: "John", Address: notationAddress{City: "Tallinn"}}, result) {
+	}
+}
+
+func Test_Require_Permit_WithNotation_Mixed(t *testing.T) {
+	values := mockQueryValues("user.name=John&user[address].city=Tallinn&user.items[0][city]=Tartu")
+	result := notationUser{}
+
+	err := Params().WithNotation(Mixed).Require("user").Permit("name, address:{city}, items:[city]").Values(values)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, notationUser{
+			Name:    "John",
+			Address: notationAddress{City: "Tallinn"},
+			Items:   []notationAddress{{City: "Tartu"}},
+		}, result) {
+	}
+}
+
+func Test_Permit_EscapedKeys_Tree(t *testing.T) {
+	values := mockQueryValues("files[report%%252Epdf]=1&files[a%%255Bb%%255D]=2&files[100%%2525]=3&files[other]=4")
+	result := Tree{}
+
+	err := Params().Permit("files:{report%2Epdf, a%5Bb%5D, 100%25}").Values(values)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, Tree{"files": Tree{"report.pdf": "1", "a[b]": "2", "100%": "3"}}, result) {
+	}
+}
+
+func Test_Permit_EscapedDot_Struct(t *testing.T) {
+	values := mockQueryValues("file%%252Ename=value")
+
+	err := Params().Permit("file%2Ename").Values(values)(&notationUser{})
+
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "`file%2Ename` contains `.` character")
+	}
+}
@@ -0,0 +1,89 @@
+package strongparamstest
+
+import (
+	"github.com/stretchr/testify/assert"
+	. "github.com/vellotis/go-strongparams"
+	"net/url"
+	"testing"
+)
+
+func Test_Parameters_Require_Permit(t *testing.T) {
+	params := NewParameters(mockQueryValues("user[name]=John&user[address][city]=Tallinn&user[admin]=true"))
+
+	user, err := params.Require("user")
+	if !assert.NoError(t, err) {
+		return
+	}
+	permitted, err := user.Permit("name, address:{city}")
+
+	if assert.NoError(t, err) &&
+		assert.False(t, user.IsPermitted()) &&
+		assert.True(t, permitted.IsPermitted()) &&
+		assert.Equal(t, "John", permitted.Fetch("name", "default")) &&
+		assert.Equal(t, "default", permitted.Fetch("admin", "default")) &&
+		assert.Equal(t, "Tallinn", permitted.Dig("address", "city")) &&
+		assert.Nil(t, permitted.Dig("address", "street")) {
+	}
+}
+
+func Test_Parameters_Require_MissingKey(t *testing.T) {
+	params := NewParameters(mockQueryValues("key=value"))
+
+	user, err := params.Require("user")
+
+	if assert.Nil(t, user) &&
+		assert.EqualError(t, err, "query: missing required key: `user`") {
+	}
+}
+
+func Test_Parameters_Dig_Array(t *testing.T) {
+	params := NewParameters(mockQueryValues("user[tags][]=tag1&user[tags][]=tag2&user[items][1][key]=key2&user[items][0][key]=key1"))
+
+	if assert.Equal(t, "tag2", params.Dig("user", "tags", "1")) &&
+		assert.Equal(t, "key2", params.Dig("user", "items", "1", "key")) &&
+		assert.Nil(t, params.Dig("user", "items", "2", "key")) {
+	}
+}
+
+func Test_Parameters_Slice_Except_Merge(t *testing.T) {
+	params := NewParameters(mockQueryValues("key1=value1&key2[sub]=value2&key3=value3"))
+	other, _ := NewParameters(mockQueryValues("key3=other3&key4=other4")).Permit("key3, key4")
+
+	sliced := params.Slice("key1", "key2")
+	excepted := params.Except("key1", "key2")
+	merged := params.Merge(other)
+
+	var mergedKeys []string
+	merged.Each(func(key string, values []string) {
+		mergedKeys = append(mergedKeys, key)
+	})
+
+	if assert.True(t, sliced.Has("key1")) &&
+		assert.True(t, sliced.Has("key2")) &&
+		assert.False(t, sliced.Has("key3")) &&
+		assert.False(t, excepted.Has("key1")) &&
+		assert.True(t, excepted.Has("key3")) &&
+		assert.False(t, merged.IsPermitted()) &&
+		assert.Equal(t, []string{"key1", "key2[sub]", "key3", "key4"}, mergedKeys) &&
+		assert.Equal(t, "other3", merged.Fetch("key3", nil)) {
+	}
+}
+
+func Test_Parameters_ToValues_ToMap(t *testing.T) {
+	params := NewParameters(mockQueryValues("obj[key]=value&ignored=value"))
+
+	_, unpermittedErr := params.ToValues()
+	permitted, err := params.Permit("obj:{key}")
+	if !assert.NoError(t, err) {
+		return
+	}
+	values, valuesErr := permitted.ToValues()
+	tree, treeErr := permitted.ToMap()
+
+	if assert.EqualError(t, unpermittedErr, "parameters are not permitted: call `Permit` before converting them") &&
+		assert.NoError(t, valuesErr) &&
+		assert.NoError(t, treeErr) &&
+		assert.Equal(t, url.Values{"obj[key]": []string{"value"}}, values) &&
+		assert.Equal(t, Tree{"obj": Tree{"key": "value"}}, tree) {
+	}
+}
@@ -0,0 +1,79 @@
+package strongparamstest
+
+import (
+	"github.com/stretchr/testify/assert"
+	. "github.com/vellotis/go-strongparams"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type pathEntity struct {
+	Org  string `params:"org"`
+	ID   int    `params:"id"`
+	Name string `params:"name"`
+}
+
+func serveMux(pattern string, target string, handler http.HandlerFunc) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(pattern, handler)
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+}
+
+func Test_Permit_FromPathValues_ServeMux(t *testing.T) {
+	var err error
+	result := pathEntity{}
+
+	serveMux("GET /orgs/{org}/users/{id}", "/orgs/acme/users/1", func(w http.ResponseWriter, r *http.Request) {
+		err = Params().Permit("org, id").PathValues(r)(&result)
+	})
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, pathEntity{Org: "acme", ID: 1}, result) {
+	}
+}
+
+func Test_Permit_FromSources_PathValuesAndQuery(t *testing.T) {
+	var err error
+	result := pathEntity{}
+
+	serveMux("GET /orgs/{org}/users/{id}", "/orgs/acme/users/1?id=2&name=John", func(w http.ResponseWriter, r *http.Request) {
+		err = Params().Permit("org, id, name").Sources(PathValues(r), Query(r))(&result)
+	})
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, pathEntity{Org: "acme", ID: 1, Name: "John"}, result) {
+	}
+}
+
+func Test_Params_FromPathValues_Adapters(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	muxVars := func(*http.Request) map[string]string {
+		return map[string]string{"org": "acme", "id": "1"}
+	}
+	chiURLParam := func(_ *http.Request, key string) string {
+		return map[string]string{"org": "other", "name": "John"}[key]
+	}
+	result := pathEntity{}
+
+	err := Params().PathValues(request, GorillaMux(muxVars), Chi(chiURLParam, "org", "name"))(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, pathEntity{Org: "acme", ID: 1, Name: "John"}, result) {
+	}
+}
+
+func Test_RequireOne_FromPathValues(t *testing.T) {
+	var value interface{}
+	var err error
+
+	serveMux("GET /files/{path...}", "/files/dir/file.txt", func(w http.ResponseWriter, r *http.Request) {
+		value, err = Params().RequireOne("path").PathValues(r)(func(value string) (string, error) {
+			return value, nil
+		})
+	})
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, "dir/file.txt", value) {
+	}
+}
@@ -0,0 +1,111 @@
+package strongparamstest
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	. "github.com/vellotis/go-strongparams"
+	"net/url"
+	"testing"
+)
+
+func Test_ParseRaw_PreservesOrder(t *testing.T) {
+	params := ParseRaw("b=2&a=1&b=3&c[d]=x+y&&e")
+
+	if assert.Equal(t, RawParams{
+		{Key: "b", Value: "2"},
+		{Key: "a", Value: "1"},
+		{Key: "b", Value: "3"},
+		{Key: "c[d]", Value: "x y"},
+		{Key: "e", Value: ""},
+	}, params) &&
+		assert.Equal(t, url.Values{"a": {"1"}, "b": {"2", "3"}, "c[d]": {"x y"}, "e": {""}}, params.Values()) {
+	}
+}
+
+func Test_ParseRaw_DropsMalformedPairs(t *testing.T) {
+	params := ParseRaw("a=1;b=2&c=%zz&=empty&d[=3&e=4")
+
+	if assert.Equal(t, RawParams{
+		{Key: "", Value: "empty"},
+		{Key: "d[", Value: "3"},
+		{Key: "e", Value: "4"},
+	}, params) {
+	}
+}
+
+func Test_ParseRawStrict(t *testing.T) {
+	params, err := ParseRawStrict("a=1;b=2&c=%zz&=empty&d[=3&e[f]g=4&h=5")
+
+	var parseErrors ParseErrors
+	var parseError *ParseError
+	if assert.Equal(t, RawParams{{Key: "h", Value: "5"}}, params) &&
+		assert.True(t, errors.As(err, &parseErrors)) &&
+		assert.Equal(t, ParseErrors{
+			{Kind: Semicolon, Pair: "a=1;b=2", Offset: 0},
+			{Kind: MalformedEscape, Pair: "c=%zz", Offset: 8},
+			{Kind: EmptyKey, Pair: "=empty", Offset: 14},
+			{Kind: InvalidBrackets, Pair: "d[=3", Offset: 21},
+			{Kind: InvalidBrackets, Pair: "e[f]g=4", Offset: 26},
+		}, parseErrors) &&
+		assert.True(t, errors.As(err, &parseError)) &&
+		assert.EqualError(t, parseError, "raw: semicolon separator in pair `a=1;b=2` at offset 0") {
+	}
+}
+
+func Test_ParseRawStrict_Valid(t *testing.T) {
+	params, err := ParseRawStrict("user[name]=John&user[tags][]=a&items[][name]=b")
+
+	if assert.NoError(t, err) &&
+		assert.Len(t, params, 3) {
+	}
+}
+
+func Test_ParseRawStrict_RootBrackets(t *testing.T) {
+	params, err := ParseRawStrict("[]=a&[]=b&[0][name]=c")
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, RawParams{{Key: "[]", Value: "a"}, {Key: "[]", Value: "b"}, {Key: "[0][name]", Value: "c"}},
+			params) {
+	}
+}
+
+func Test_Require_Permit_Raw(t *testing.T) {
+	result := struct {
+		Name string   `params:"name"`
+		Tags []string `params:"tags"`
+	}{}
+
+	err := Params().Require("user").Permit("name, tags:[]").Raw("user[name]=John;x&user[tags][]=a&user[tags][]=b")(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, "", result.Name) &&
+		assert.Equal(t, []string{"a", "b"}, result.Tags) {
+	}
+}
+
+func Test_WithStrictParsing_Raw(t *testing.T) {
+	result := struct {
+		Name string `params:"name"`
+	}{}
+
+	errStrict := Params().WithStrictParsing().Permit("name").Raw("name=John&x=%zz")(&result)
+	errLenient := Params().Permit("name").Raw("name=John&x=%zz")(&result)
+
+	var parseError *ParseError
+	if assert.True(t, errors.As(errStrict, &parseError)) &&
+		assert.Equal(t, MalformedEscape, parseError.Kind) &&
+		assert.NoError(t, errLenient) &&
+		assert.Equal(t, "John", result.Name) {
+	}
+}
+
+func Test_WithStrictParsing_RequireOne_Sources_Raw(t *testing.T) {
+	_, err := Params().WithStrictParsing().RequireOne("name").Sources(Raw("name=John&=x"))(func(value string) (string, error) {
+		return value, nil
+	})
+
+	var parseError *ParseError
+	if assert.True(t, errors.As(err, &parseError)) &&
+		assert.Equal(t, EmptyKey, parseError.Kind) {
+	}
+}
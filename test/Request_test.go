@@ -0,0 +1,85 @@
+package strongparamstest
+
+import (
+	"github.com/stretchr/testify/assert"
+	. "github.com/vellotis/go-strongparams"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type requestEntity struct {
+	Key1 string `params:"key1"`
+	Key2 int    `params:"key2"`
+}
+
+func mockRequestWithBody(method string, target string, contentType string, body string) *http.Request {
+	request := httptest.NewRequest(method, target, strings.NewReader(body))
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+	return request
+}
+
+func Test_Require_Permit_FromRequest(t *testing.T) {
+	requests := map[string]*http.Request{
+		"query":  httptest.NewRequest(http.MethodGet, "/?entity[key1]=value&entity[key2]=4&entity[key3]=ignored", nil),
+		"delete": httptest.NewRequest(http.MethodDelete, "/?entity[key1]=value&entity[key2]=4", nil),
+		"form": mockRequestWithBody(http.MethodPost, "/", "application/x-www-form-urlencoded",
+			"entity[key1]=value&entity[key2]=4&entity[key3]=ignored"),
+		"multipart": mockMultipartRequest(map[string]string{"entity[key1]": "value", "entity[key2]": "4"}),
+		"json": mockRequestWithBody(http.MethodPut, "/", "application/json; charset=utf-8",
+			`{"entity":{"key1":"value","key2":4,"key3":"ignored"}}`),
+		"json suffix": mockRequestWithBody(http.MethodPatch, "/", "application/vnd.api+json",
+			`{"entity":{"key1":"value","key2":4}}`),
+	}
+
+	for name, request := range requests {
+		result := requestEntity{}
+
+		err := Params().Require("entity").Permit("key1, key2").Request(request)(&result)
+
+		if assert.NoError(t, err, name) &&
+			assert.Equal(t, requestEntity{Key1: "value", Key2: 4}, result, name) {
+		}
+	}
+}
+
+func Test_Params_FromRequest_BodyPrecedesQuery(t *testing.T) {
+	request := mockRequestWithBody(http.MethodPost, "/?key1=query&key2=4", "application/x-www-form-urlencoded",
+		"key1=body")
+	result := requestEntity{}
+
+	err := Params().Request(request)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, requestEntity{Key1: "body", Key2: 4}, result) {
+	}
+}
+
+func Test_Params_FromRequest_Errors(t *testing.T) {
+	result := requestEntity{}
+
+	errUnsupported := Params().Request(mockRequestWithBody(http.MethodPost, "/", "text/plain", "key1=value"))(&result)
+	errMissing := Params().Request(mockRequestWithBody(http.MethodPost, "/", "", "key1=value"))(&result)
+	errLimit := Params().WithRequestLimits(RequestLimits{MaxBodyBytes: 5}).
+		Request(mockRequestWithBody(http.MethodPost, "/", "application/json", `{"key1":"value"}`))(&result)
+
+	if assert.EqualError(t, errUnsupported, "request: unsupported `Content-Type` of the request body: `text/plain`") &&
+		assert.EqualError(t, errMissing, "request: missing `Content-Type` header of the request body") &&
+		assert.Equal(t, &LimitError{Limit: "MaxBodyBytes", Max: 5}, errLimit) {
+	}
+}
+
+func Test_RequireOne_FromRequest(t *testing.T) {
+	request := mockRequestWithBody(http.MethodPost, "/", "application/x-www-form-urlencoded", "key=4")
+
+	value, err := Params().RequireOne("key").Request(request)(func(value string) (string, error) {
+		return value, nil
+	})
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, "4", value) {
+	}
+}
@@ -0,0 +1,105 @@
+package strongparamstest
+
+import (
+	"github.com/stretchr/testify/assert"
+	. "github.com/vellotis/go-strongparams"
+	"strings"
+	"testing"
+)
+
+type sanitizedUser struct {
+	Email string   `params:"email"`
+	Bio   string   `params:"bio"`
+	Tags  []string `params:"tags"`
+	Items []struct {
+		Name string `params:"name"`
+	} `params:"items"`
+}
+
+func Test_Require_Permit_WithSanitizers(t *testing.T) {
+	values := mockQueryValues("user[email]=%%20John@Example.COM%%20&user[bio]=%%3Cb%%3EHi%%3C/b%%3E%%01%%20%%20there&user[tags][]=%%20a%%20&user[tags][]=B&user[items][][name]=%%20x%%20")
+	result := sanitizedUser{}
+
+	err := Params().
+		WithSanitizers("user[email]: trim, lower", "user[bio]: strip_tags,strip_control,squish", "user[tags][]: trim", "user[items][][name]: trim").
+		Require("user").Permit("email, bio, tags:[], items:[name]").Values(values)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, "john@example.com", result.Email) &&
+		assert.Equal(t, "Hi there", result.Bio) &&
+		assert.Equal(t, []string{"a", "B"}, result.Tags) &&
+		assert.Len(t, result.Items, 1) &&
+		assert.Equal(t, "x", result.Items[0].Name) {
+	}
+}
+
+func Test_Require_Permit_WithSanitizers_RequiredKey(t *testing.T) {
+	values := mockQueryValues("user[email]=%%20A@B.C&user[bio]=%%20Bio")
+	result := sanitizedUser{}
+
+	err := Params().WithSanitizers("user: trim").Require("user").Permit("email, bio").Values(values)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, sanitizedUser{Email: "A@B.C", Bio: "Bio"}, result) {
+	}
+}
+
+func Test_Permit_WithSanitizer_Custom(t *testing.T) {
+	values := mockQueryValues("email=John@Example.COM")
+	result := Tree{}
+
+	err := Params().WithSanitizer("email", SanitizerFunc(strings.ToUpper)).Permit("email").Values(values)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, Tree{"email": "JOHN@EXAMPLE.COM"}, result) {
+	}
+}
+
+func Test_Permit_WithSanitizers_RegisterSanitizer(t *testing.T) {
+	RegisterSanitizer("digits", SanitizerFunc(func(value string) string {
+		return strings.Map(func(r rune) rune {
+			if r < '0' || r > '9' {
+				return -1
+			}
+			return r
+		}, value)
+	}))
+	t.Cleanup(func() {
+		UnregisterSanitizer("digits")
+	})
+	values := mockQueryValues("phone=%%2B1 (555) 010-99")
+	result := Tree{}
+
+	err := Params().WithSanitizers("phone: digits").Permit("phone").Values(values)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, Tree{"phone": "155501099"}, result) {
+	}
+}
+
+func Test_Permit_WithSanitizers_InvalidDeclarations(t *testing.T) {
+	values := mockQueryValues("email=a")
+
+	errUnknown := Params().WithSanitizers("email: trim, unknown").Permit("email").Values(values)(&Tree{})
+	errInvalid := Params().WithSanitizers("email trim").Permit("email").Values(values)(&Tree{})
+	_, errRequireOne := Params().WithSanitizers("email: unknown").RequireOne("email").Values(values)(
+		func(value string) (string, error) {
+			return value, nil
+		})
+
+	if assert.EqualError(t, errUnknown, "sanitizers: unknown sanitizer `unknown` of path `email`") &&
+		assert.EqualError(t, errInvalid, "sanitizers: invalid declaration `email trim`") &&
+		assert.EqualError(t, errRequireOne, "sanitizers: unknown sanitizer `unknown` of path `email`") {
+	}
+}
+
+func Test_RequireOne_WithSanitizers(t *testing.T) {
+	value, err := Params().WithSanitizers("email: trim,lower").RequireOne("email").Values(mockQueryValues("email=%%20A@B.C"))(
+		func(value string) (string, error) {
+			return value, nil
+		})
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, "a@b.c", value) {
+	}
+}
@@ -0,0 +1,82 @@
+package strongparamstest
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	. "github.com/vellotis/go-strongparams"
+	"testing"
+)
+
+type schemaUserInput struct {
+	Name  string   `params:"name"`
+	Email string   `params:"email"`
+	Tags  []string `params:"tags"`
+}
+
+var createUserSchema = NewSchema[schemaUserInput]("user", "name, email")
+
+func Test_Schema_Bind(t *testing.T) {
+	request := mockRequestWithQuery("user[name]=John&user[email]=john@example.com&user[admin]=true")
+
+	input, err := createUserSchema.Bind(request)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, schemaUserInput{Name: "John", Email: "john@example.com"}, input) {
+	}
+}
+
+func Test_Schema_BindValues_MissingRequiredKey(t *testing.T) {
+	values := mockQueryValues("name=John")
+
+	_, err := createUserSchema.BindValues(values)
+	_, errRepeated := createUserSchema.BindValues(mockQueryValues("user[name]=John"))
+
+	if assert.EqualError(t, err, "query: missing required key: `user`") &&
+		assert.NoError(t, errRepeated) {
+	}
+}
+
+func Test_Schema_Tree(t *testing.T) {
+	schema := NewSchema[Tree]("", "key")
+
+	tree, err := schema.BindValues(mockQueryValues("key=value&ignored=value"))
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, Tree{"key": "value"}, tree) {
+	}
+}
+
+func Test_NewSchema_PanicsOnInconsistentRules(t *testing.T) {
+	assert.Panics(t, func() {
+		NewSchema[schemaUserInput]("user", "name, emial")
+	})
+}
+
+func Test_Schema_Defaults(t *testing.T) {
+	schema := NewSchema[listParams]("list", "page, per_page=25, status:[]")
+
+	input, err := schema.BindValues(mockQueryValues("list[page]=2"))
+	inputOverridden, errOverridden := schema.BindValues(mockQueryValues("list[page]=3&list[per_page]=50"))
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, listParams{Page: 2, PerPage: 25}, input) &&
+		assert.NoError(t, errOverridden) &&
+		assert.Equal(t, listParams{Page: 3, PerPage: 50}, inputOverridden) {
+	}
+}
+
+func Test_Schema_CrossFieldRules(t *testing.T) {
+	schema := NewSchema[searchParams]("search", "email, phone, oneOf(email, phone)")
+
+	input, err := schema.BindValues(mockQueryValues("search[email]=a@b.c"))
+	_, errBoth := schema.BindValues(mockQueryValues("search[email]=a@b.c&search[phone]=123"))
+
+	var crossFieldErrors CrossFieldErrors
+	if assert.NoError(t, err) &&
+		assert.Equal(t, "a@b.c", input.Email) &&
+		assert.True(t, errors.As(errBoth, &crossFieldErrors)) &&
+		assert.Equal(t, CrossFieldErrors{
+			{Kind: OneOfRule, Keys: []string{"search[email]", "search[phone]"}, Present: []string{"search[email]", "search[phone]"}},
+		}, crossFieldErrors) {
+	}
+}
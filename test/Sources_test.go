@@ -0,0 +1,88 @@
+package strongparamstest
+
+import (
+	"github.com/stretchr/testify/assert"
+	. "github.com/vellotis/go-strongparams"
+	"net/http"
+	"testing"
+)
+
+type sourcesEntity struct {
+	ID   int    `params:"id"`
+	Name string `params:"name"`
+	Age  int    `params:"age"`
+}
+
+func Test_Require_Permit_FromSources(t *testing.T) {
+	request := mockRequestWithBody(http.MethodPost, "/?user[id]=2&user[name]=query", "application/json",
+		`{"user":{"name":"body","age":30,"admin":true}}`)
+	path := Values("path", mockQueryValues("user[id]=1"))
+	result := sourcesEntity{}
+
+	err := Params().Require("user").Permit("id, name, age").Sources(path, Query(request), Body(request))(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, sourcesEntity{ID: 1, Name: "query", Age: 30}, result) {
+	}
+}
+
+func Test_Params_FromSources_LastWins(t *testing.T) {
+	request := mockRequestWithBody(http.MethodPost, "/?id=2&name=query", "application/x-www-form-urlencoded",
+		"name=body")
+	result := sourcesEntity{}
+
+	err := Params().WithMergePolicy(LastWins).Sources(Query(request), Body(request))(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, sourcesEntity{ID: 2, Name: "body"}, result) {
+	}
+}
+
+func Test_Params_FromSources_ErrorOnConflict(t *testing.T) {
+	params := Params().WithMergePolicy(ErrorOnConflict)
+	result := sourcesEntity{}
+
+	err := params.Sources(
+		Values("path", mockQueryValues("id=1")),
+		Values("query", mockQueryValues("id=2")),
+	)(&result)
+	errEqual := params.Sources(
+		Values("path", mockQueryValues("id=1")),
+		Values("query", mockQueryValues("id=1")),
+	)(&result)
+	errUnpermitted := params.Permit("name").Sources(
+		Values("path", mockQueryValues("id=1&name=John")),
+		Values("query", mockQueryValues("id=2")),
+	)(&result)
+
+	if assert.EqualError(t, err, "sources: conflicting values of key `id` from `path` and `query`") &&
+		assert.NoError(t, errEqual) &&
+		assert.NoError(t, errUnpermitted) {
+	}
+}
+
+func Test_Params_FromSources_ErrorNamesSource(t *testing.T) {
+	result := sourcesEntity{}
+
+	err := Params().Sources(
+		Values("path", mockQueryValues("id=1")),
+		Values("query", mockQueryValues("age=old")),
+	)(&result)
+
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "source `query`: schema: error converting value for \"age\"")
+	}
+}
+
+func Test_RequireOne_FromSources(t *testing.T) {
+	value, err := Params().RequireOne("id").Sources(
+		Values("path", mockQueryValues("id=1")),
+		Values("query", mockQueryValues("id=2")),
+	)(func(value string) (string, error) {
+		return value, nil
+	})
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, "1", value) {
+	}
+}
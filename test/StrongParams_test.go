@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	. "github.com/vellotis/go-strongparams"
+	"net/url"
 	"strconv"
 	"testing"
 )
@@ -44,6 +45,36 @@ func Test_Params_FromQuery(t *testing.T) {
 	}
 }
 
+func Test_Params_FromQuery_EmptyBracketArrayKeys(t *testing.T) {
+	request := mockRequestWithQuery("ids[]=1&ids[]=2&names=a&names[]=b")
+	type ArrayValues struct {
+		Ids   []int    `params:"ids"`
+		Names []string `params:"names"`
+	}
+	result := ArrayValues{}
+
+	err := Params().Query(request)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, []int{1, 2}, result.Ids) &&
+		assert.ElementsMatch(t, []string{"a", "b"}, result.Names) {
+	}
+}
+
+func Test_Params_FromValues_KeyWithoutValues(t *testing.T) {
+	type KeyValue struct {
+		Key   string `params:"key"`
+		Other string `params:"other"`
+	}
+	result := KeyValue{Key: "untouched"}
+
+	err := Params().Values(url.Values{"key": []string{}, "other": []string{"value"}})(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, KeyValue{Key: "untouched", Other: "value"}, result) {
+	}
+}
+
 func Test_Params_FromPostForm(t *testing.T) {
 	expectedValue := "value"
 	request := mockRequestWithPostForm("root[key]=" + expectedValue)
@@ -356,3 +387,41 @@ func Test_Require_Permit_AdvancedStruct(t *testing.T) {
 		assert.Equal(t, "value2", result.Arr[1].Value) {
 	}
 }
+
+func Test_PermitStruct(t *testing.T) {
+	values := mockQueryValues("name=John&admin=true&address[street]=Street&address[city]=City")
+	type User struct {
+		Name    string `params:"name"`
+		Admin   bool   `params:"admin" permit:"admin"`
+		Address struct {
+			Street string `params:"street"`
+		} `params:"address"`
+	}
+	result := User{}
+	resultAsAdmin := User{}
+
+	err := Params().PermitStruct(&result).Values(values)(&result)
+	errAsAdmin := Params().PermitStruct(&resultAsAdmin, "admin").Values(values)(&resultAsAdmin)
+
+	if assert.NoError(t, err) &&
+		assert.NoError(t, errAsAdmin) &&
+		assert.Equal(t, "John", result.Name) &&
+		assert.Equal(t, "Street", result.Address.Street) &&
+		assert.False(t, result.Admin) &&
+		assert.True(t, resultAsAdmin.Admin) {
+	}
+}
+
+func Test_Require_PermitStruct(t *testing.T) {
+	values := mockQueryValues("root[key]=value&root[ignored]=value")
+	type KeyValue struct {
+		Key string `params:"key"`
+	}
+	result := KeyValue{}
+
+	err := Params().Require("root").PermitStruct(&result).Values(values)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, "value", result.Key) {
+	}
+}
@@ -0,0 +1,49 @@
+package strongparamstest
+
+import (
+	"github.com/stretchr/testify/assert"
+	. "github.com/vellotis/go-strongparams"
+	"testing"
+)
+
+func Test_Params_Tree(t *testing.T) {
+	values := mockQueryValues("key=value&multi=value1&multi=value2&obj[sub]=subValue&arr[]=arrValue")
+	result := Tree{}
+
+	err := Params().Values(values)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, Tree{
+			"key":   "value",
+			"multi": []interface{}{"value1", "value2"},
+			"obj":   Tree{"sub": "subValue"},
+			"arr":   []interface{}{"arrValue"},
+		}, result) {
+	}
+}
+
+func Test_Require_Permit_Map(t *testing.T) {
+	values := mockQueryValues("root[arr][1][key]=key2&root[arr][0][key]=key1&root[arr][10][key]=key3&root[ignored]=value")
+	result := map[string]interface{}{}
+
+	err := Params().Require("root").Permit("arr:[key]").Values(values)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, map[string]interface{}{
+			"arr": []interface{}{
+				Tree{"key": "key1"},
+				Tree{"key": "key2"},
+				Tree{"key": "key3"},
+			},
+		}, result) {
+	}
+}
+
+func Test_Params_Tree_ConflictingKeys(t *testing.T) {
+	values := mockQueryValues("key=value&key[sub]=value")
+	result := Tree{}
+
+	err := Params().Values(values)(&result)
+
+	assert.EqualError(t, err, "key `key` is used both as a value and as a nested object")
+}
@@ -0,0 +1,124 @@
+package strongparamstest
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/schema"
+	"github.com/stretchr/testify/assert"
+	. "github.com/vellotis/go-strongparams"
+	"strings"
+	"testing"
+)
+
+type BaseInput struct {
+	Email string `params:"email" validate:"required"`
+}
+
+type UserInput struct {
+	BaseInput
+	Name    string `params:"name" validate:"required"`
+	Address struct {
+		Zip string `params:"zip" validate:"required"`
+	} `params:"address"`
+	Items []struct {
+		Title string `params:"title" validate:"required"`
+	} `params:"items" validate:"dive"`
+}
+
+func Test_WithValidator_PlaygroundValidator(t *testing.T) {
+	values := mockQueryValues("user[name]=John&user[items][0][title]=a&user[items][1][title]=")
+
+	err := Params().WithValidator(PlaygroundValidator(validator.New())).
+		Require("user").Permit("email, name, address:{zip}, items:[title]").Values(values)(&UserInput{})
+
+	var validationErrors ValidationErrors
+	if assert.True(t, errors.As(err, &validationErrors)) &&
+		assert.Equal(t, ValidationErrors{
+			{Path: "user[address][zip]", Message: "failed on the `required` rule"},
+			{Path: "user[email]", Message: "failed on the `required` rule"},
+			{Path: "user[items][1][title]", Message: "failed on the `required` rule"},
+		}, validationErrors) {
+	}
+}
+
+func Test_WithValidator_PlaygroundValidator_ManyElements(t *testing.T) {
+	var query []string
+	for idx := 0; idx < 11; idx++ {
+		query = append(query, fmt.Sprintf("items[%d][title]=%d", idx, idx))
+	}
+	values := mockQueryValues("email=a@b.c&name=John&address[zip]=123&" + strings.Join(query, "&") + "&items[11][title]=")
+
+	err := Params().WithValidator(PlaygroundValidator(validator.New())).
+		Permit("email, name, address:{zip}, items:[title]").Values(values)(&UserInput{})
+
+	if assert.EqualError(t, err, "validation: `items[11][title]` failed on the `required` rule") {
+	}
+}
+
+func Test_WithValidator_Valid(t *testing.T) {
+	values := mockQueryValues("email=a@b.c&name=John&address[zip]=123")
+	result := UserInput{}
+
+	err := Params().WithValidator(PlaygroundValidator(validator.New())).Values(values)(&result)
+
+	if assert.NoError(t, err) &&
+		assert.Equal(t, "123", result.Address.Zip) {
+	}
+}
+
+func Test_WithValidator_MergedWithConstraints(t *testing.T) {
+	values := mockQueryValues("user[email]=a@b.c&user[name]=Johnny&user[address][zip]=")
+
+	err := Params().WithValidator(PlaygroundValidator(validator.New())).
+		Require("user").Permit("email, name:<=4, address:{zip}").Values(values)(&UserInput{})
+
+	if assert.EqualError(t, err, "validation: `user[address][zip]` failed on the `required` rule; "+
+		"`user[name]` must be at most 4 characters") {
+	}
+}
+
+func Test_WithValidator_DecodeErrorWithConstraints(t *testing.T) {
+	values := mockQueryValues("email=a@b.c&name=Johnny&age=abc")
+	result := struct {
+		UserInput
+		Age int `params:"age"`
+	}{}
+
+	err := Params().WithValidator(PlaygroundValidator(validator.New())).
+		Permit("email, name:<=4, age").Values(values)(&result)
+
+	var validationErrors ValidationErrors
+	var multiError schema.MultiError
+	if assert.True(t, errors.As(err, &validationErrors)) &&
+		assert.Equal(t, ValidationErrors{{Path: "name", Message: "must be at most 4 characters"}}, validationErrors) &&
+		assert.True(t, errors.As(err, &multiError)) &&
+		assert.Contains(t, multiError, "age") {
+	}
+}
+
+func Test_WithValidator_ValidatorFunc(t *testing.T) {
+	values := mockQueryValues("user[name]=John")
+	invalid := errors.New("invalid")
+
+	err := Params().WithValidator(ValidatorFunc(func(target interface{}, aliasTag string) error {
+		if aliasTag != "params" {
+			return invalid
+		}
+		return ValidationErrors{{Path: "name", Message: "is taken"}}
+	})).Require("user").Values(values)(&UserInput{})
+
+	if assert.EqualError(t, err, "validation: `user[name]` is taken") {
+	}
+}
+
+func Test_WithValidator_OtherError(t *testing.T) {
+	invalid := errors.New("invalid")
+
+	err := Params().WithValidator(ValidatorFunc(func(target interface{}, aliasTag string) error {
+		return invalid
+	})).Values(mockQueryValues("name=John"))(&UserInput{})
+
+	if assert.Equal(t, invalid, err) {
+	}
+}
@@ -3,7 +3,9 @@ package strongparamstest
 import (
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"strings"
 )
 
 func mockQueryValues(query string, args ...interface{}) url.Values {
@@ -22,4 +24,10 @@ func mockRequestWithPostForm(query string, args ...interface{}) *http.Request {
 	return &http.Request{
 		PostForm: mockQueryValues(query, args...),
 	}
-}
\ No newline at end of file
+}
+
+func mockRequestWithJSON(body string, args ...interface{}) *http.Request {
+	request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(fmt.Sprintf(body, args...)))
+	request.Header.Set("Content-Type", "application/json")
+	return request
+}